@@ -169,10 +169,12 @@ func (actionArgument *ActionArgument) FindSelectedRecords() []interface{} {
 		return records
 	}
 
+	// clone qor context, so conditions of selected records won't be leaked into action's context
 	clone := context.clone()
+	clone.Context = context.Context.Clone()
 	for _, primaryValue := range actionArgument.PrimaryValues {
 		primaryQuerySQL, primaryParams := resource.ToPrimaryQueryParams(primaryValue, context.Context)
-		sqls = append(sqls, primaryQuerySQL)
+		sqls = append(sqls, "("+primaryQuerySQL+")")
 		sqlParams = append(sqlParams, primaryParams...)
 	}
 
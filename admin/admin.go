@@ -12,6 +12,7 @@ import (
 	"github.com/saitofun/qor/qor"
 	"github.com/saitofun/qor/qor/resource"
 	"github.com/saitofun/qor/qor/utils"
+	"github.com/saitofun/qor/roles"
 	"github.com/saitofun/qor/session"
 	"github.com/saitofun/qor/session/manager"
 	"github.com/theplant/cldr"
@@ -27,6 +28,20 @@ type AdminConfig struct {
 	SessionManager  session.ManagerInterface
 	SettingsStorage SettingsStorageInterface
 	I18n            I18n
+	// TenantResolver resolve tenant of requests, e.g: qor.TenantFromSubdomain("example.com")
+	TenantResolver qor.TenantResolver
+	// DBResolver resolve db for operations, e.g: qor.ReadReplicas(replica) to list records from a read replica
+	DBResolver qor.DBResolver
+	// AuditLog record who created, updated, deleted records or executed actions from admin interface, audit logs could be browsed from its read-only resource that is registered automatically
+	AuditLog bool
+	// TrustedProxies IP addresses or CIDRs of trusted proxies, client IP addresses of audit logs are read from `X-Forwarded-For` or `X-Real-Ip` only if requests are sent from them
+	TrustedProxies []string
+	// GlobalSettingsPermission permission to save settings of global layer, which are shared with all users, e.g: `roles.Allow(roles.Update, "admin")`,
+	// settings of global layer couldn't be saved if it is nil
+	GlobalSettingsPermission *roles.Permission
+	// RoleSettingsPermission permission to save settings of role layer, which are shared with users of the role, e.g: `roles.Allow(roles.Update, "manager")`,
+	// settings could only be saved for roles that have the permission, and settings of role layer couldn't be saved if it is nil
+	RoleSettingsPermission *roles.Permission
 	*Transformer
 }
 
@@ -50,7 +65,7 @@ func New(config interface{}) *Admin {
 	}
 
 	if c, ok := config.(*qor.Config); ok {
-		admin.AdminConfig = &AdminConfig{DB: c.DB}
+		admin.AdminConfig = &AdminConfig{DB: c.DB, TenantResolver: c.TenantResolver, DBResolver: c.DBResolver}
 	} else if c, ok := config.(*AdminConfig); ok {
 		admin.AdminConfig = c
 	} else {
@@ -79,7 +94,10 @@ func New(config interface{}) *Admin {
 		admin.AdminConfig.DB.AutoMigrate(&QorAdminSetting{})
 	}
 
-	admin.registerCompositePrimaryKeyCallback()
+	if admin.AuditLog {
+		admin.configureAuditLog()
+	}
+
 	return &admin
 }
 
@@ -152,6 +170,22 @@ func (admin *Admin) newResource(value interface{}, config ...*Config) *Resource
 
 	res.Permission = configuration.Permission
 
+	if configuration.TenantColumn != "" {
+		res.SetTenantColumn(configuration.TenantColumn)
+	}
+
+	if configuration.Cache != nil {
+		res.SetCache(configuration.Cache)
+	}
+
+	if configuration.DB != nil {
+		res.SetDB(configuration.DB)
+	}
+
+	if configuration.DBResolver != nil {
+		res.SetDBResolver(configuration.DBResolver)
+	}
+
 	if configuration.Name != "" {
 		res.Name = configuration.Name
 	} else if namer, ok := value.(ResourceNamer); ok {
@@ -188,6 +222,17 @@ func (admin *Admin) newResource(value interface{}, config ...*Config) *Resource
 func (admin *Admin) NewResource(value interface{}, config ...*Config) *Resource {
 	res := admin.newResource(value, config...)
 	res.Config.Invisible = true
+
+	// scope with tenant column of the registered resource that has the same model, e.g: resources of nested metas
+	if res.GetTenantField() == nil {
+		for _, r := range admin.resources {
+			if r.GetTenantField() != nil && utils.ModelType(r.Value) == utils.ModelType(value) {
+				res.SetTenantColumn(r.GetTenantField().Name)
+				break
+			}
+		}
+	}
+
 	res.configure()
 	return res
 }
@@ -199,6 +244,14 @@ func (admin *Admin) AddResource(value interface{}, config ...*Config) *Resource
 
 	res.configure()
 
+	if _, ok := res.Value.(*QorAuditLog); admin.AuditLog && !ok {
+		res.configureAuditHooks()
+	}
+
+	if res.Config.Versioning {
+		res.configureVersioning()
+	}
+
 	if !res.Config.Invisible {
 		res.Action(&Action{
 			Name:   "Delete",
@@ -209,6 +262,7 @@ func (admin *Admin) AddResource(value interface{}, config ...*Config) *Resource
 			Permission: res.Config.Permission,
 			Modes:      []string{"menu_item"},
 		})
+		res.configureTrash()
 
 		menuName := res.Name
 		if !res.Config.Singleton {
@@ -245,6 +299,19 @@ func (admin *Admin) GetResource(name string) (resource *Resource) {
 	return
 }
 
+// dbOf get db of admin-owned tables from context, e.g: settings, versions and audit logs, they are always saved into admin's DB,
+// context's db is used if it is a session of admin's DB, e.g: a transaction, so they are saved with the change
+func (admin *Admin) dbOf(context *qor.Context) *gorm.DB {
+	db := context.GetDB()
+	if admin.DB == nil || qor.SameDB(db, admin.DB) {
+		return db
+	}
+
+	adminContext := *context
+	adminContext.Config, adminContext.DB = &qor.Config{DB: admin.DB}, nil
+	return adminContext.GetDB()
+}
+
 // AddSearchResource make a resource searchable from search center
 func (admin *Admin) AddSearchResource(resources ...*Resource) {
 	admin.searchResources = append(admin.searchResources, resources...)
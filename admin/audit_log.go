@@ -0,0 +1,285 @@
+package admin
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/roles"
+)
+
+// AuditLogResourceName name of the auto registered audit log resource
+const AuditLogResourceName = "Audit Log"
+
+// AuditorRole role that could read audit logs, audit logs are denied for other roles, register it with `roles.Register`
+const AuditorRole = "auditor"
+
+// QorAuditLog audit log of changes made from admin interface, it is written in the same transaction with the change
+type QorAuditLog struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UserID       string
+	UserName     string
+	ResourceName string `gorm:"index:idx_qor_audit_log_record"`
+	ResourceID   string `gorm:"index:idx_qor_audit_log_record"`
+	Action       string
+	Changes      string `gorm:"size:65532"`
+	IPAddress    string
+	UserAgent    string `gorm:"size:1024"`
+}
+
+// GetChanges get field-level before/after values of the log
+func (log QorAuditLog) GetChanges() (changes resource.ChangeSet) {
+	json.Unmarshal([]byte(log.Changes), &changes)
+	return
+}
+
+// configureAuditLog migrate audit logs and register its read-only resource, audit logs could be filtered by resource and primary value,
+// only AuditorRole could read them
+func (admin *Admin) configureAuditLog() {
+	if admin.DB != nil {
+		admin.DB.AutoMigrate(&QorAuditLog{})
+	}
+
+	res := admin.AddResource(&QorAuditLog{}, &Config{
+		Name:       AuditLogResourceName,
+		Permission: roles.Allow(roles.Read, AuditorRole),
+	})
+
+	res.IndexAttrs("CreatedAt", "UserName", "ResourceName", "ResourceID", "Action", "Changes", "IPAddress")
+	res.ShowAttrs("CreatedAt", "UserID", "UserName", "ResourceName", "ResourceID", "Action", "Changes", "IPAddress", "UserAgent")
+	res.SearchAttrs("UserName", "ResourceName", "ResourceID", "Action")
+	res.Meta(&Meta{Name: "Changes", FormattedValuer: func(record interface{}, context *qor.Context) interface{} {
+		var changes []string
+		for _, change := range record.(*QorAuditLog).GetChanges() {
+			changes = append(changes, fmt.Sprintf("%v: %v => %v", change.Path, formatAuditValue(change.OldValue), formatAuditValue(change.NewValue)))
+		}
+		return strings.Join(changes, "\n")
+	}})
+
+	for _, name := range []string{"ResourceName", "ResourceID"} {
+		res.Filter(&Filter{Name: name, Operations: []string{"eq"}})
+	}
+}
+
+func formatAuditValue(value interface{}) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprint(value)
+}
+
+type auditNewRecordsKey struct{}
+
+// configureAuditHooks write audit logs of created, updated and deleted records with lifecycle hooks, so they are in the same transaction with the change
+func (res *Resource) configureAuditHooks() {
+	res.AddHook(resource.BeforeSave, &resource.Hook{
+		Name: "qor:audit_log",
+		Handler: func(record interface{}, context *qor.Context) error {
+			if isNewRecord(record) && reflect.TypeOf(record).Comparable() {
+				newRecords, ok := context.GetContext().Value(auditNewRecordsKey{}).(map[interface{}]bool)
+				if !ok {
+					newRecords = map[interface{}]bool{}
+					context.SetContext(stdcontext.WithValue(context.GetContext(), auditNewRecordsKey{}, newRecords))
+				}
+				newRecords[record] = true
+			}
+			return nil
+		},
+	})
+
+	res.AddHook(resource.AfterSave, &resource.Hook{
+		Name: "qor:audit_log",
+		Handler: func(record interface{}, context *qor.Context) error {
+			action := "update"
+			if newRecords, ok := context.GetContext().Value(auditNewRecordsKey{}).(map[interface{}]bool); ok && newRecords[record] {
+				action = "create"
+				delete(newRecords, record)
+			}
+			return res.writeAuditLog(action, res.PrimaryValueOf(record), resource.GetChangeSet(context, record), context)
+		},
+	})
+
+	res.AddHook(resource.AfterDelete, &resource.Hook{
+		Name: "qor:audit_log",
+		Handler: func(record interface{}, context *qor.Context) error {
+			var changes resource.ChangeSet
+			if schema, err := gorm.Parse(record); err == nil {
+				for _, field := range schema.Fields {
+					if value, zero := field.ValueOf(reflect.ValueOf(record)); field.FieldType.Kind() != reflect.Interface && gorm.IsNormalField(field) && !zero {
+						changes = append(changes, resource.Change{Path: field.Name, Name: field.Name, OldValue: value})
+					}
+				}
+			}
+			return res.writeAuditLog("delete", res.PrimaryValueOf(record), changes, context)
+		},
+	})
+}
+
+// writeAuditLog write audit log with context's db, user, IP address and user agent, logs won't be written in dry run mode,
+// values of masked metas are always masked as readers of logs are unknown
+func (res *Resource) writeAuditLog(action string, primaryValue string, changes resource.ChangeSet, context *qor.Context) error {
+	if context.DryRun {
+		return nil
+	}
+
+	log := QorAuditLog{ResourceName: res.ToParam(), ResourceID: primaryValue, Action: action}
+	if len(changes) > 0 {
+		value, err := json.Marshal(resource.MaskChangeSet(res, changes, nil))
+		if err != nil {
+			return err
+		}
+		log.Changes = string(value)
+	}
+
+	db := res.GetAdmin().dbOf(context)
+	log.UserID, log.UserName = currentUserOf(context)
+
+	if request := context.Request; request != nil {
+		log.IPAddress = res.GetAdmin().clientIP(request)
+		log.UserAgent = request.UserAgent()
+	}
+
+	return db.Session(&gorm.Session{}).Create(&log).Error
+}
+
+// currentUserOf id and display name of current user, which is got from `qor:current_user` of context's db or context's CurrentUser,
+// user's id is its GetID if it implements qor.IdentifiableCurrentUser, or its encoded primary value
+func currentUserOf(context *qor.Context) (id string, name string) {
+	user := context.CurrentUser
+	if value, ok := context.GetDB().Get("qor:current_user"); ok {
+		if currentUser, ok := value.(qor.CurrentUser); ok {
+			user = currentUser
+		}
+	}
+
+	if value := reflect.ValueOf(user); !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return
+	}
+
+	name = user.DisplayName()
+	if id = qor.CurrentUserID(user); id != "" {
+		return
+	}
+
+	if schema, err := gorm.Parse(user); err == nil {
+		var values []string
+		for _, field := range schema.PrimaryFields {
+			value, _ := field.ValueOf(reflect.ValueOf(user))
+			values = append(values, fmt.Sprint(value))
+		}
+		id = resource.EncodePrimaryValues(values...)
+	}
+	return
+}
+
+// handleActionWithAuditLog run action's handler and write audit logs of selected records in a transaction,
+// logs won't be written if the handler failed
+func (res *Resource) handleActionWithAuditLog(action *Action, argument *ActionArgument) error {
+	context := argument.Context
+	return context.Transaction(func(*gorm.DB) error {
+		if err := action.Handler(argument); err != nil {
+			return err
+		}
+
+		if len(argument.PrimaryValues) == 0 {
+			return res.writeAuditLog(action.Name, "", nil, context.Context)
+		}
+
+		for _, primaryValue := range argument.PrimaryValues {
+			if err := res.writeAuditLog(action.Name, primaryValue, nil, context.Context); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// clientIP IP address of the request's client, which is its remote address, `X-Forwarded-For` and `X-Real-Ip` are read only if the request is sent from trusted proxies,
+// addresses of `X-Forwarded-For` are read from right to left, the first one that isn't a trusted proxy is the client
+func (admin *Admin) clientIP(request *http.Request) string {
+	ip := parseIP(request.RemoteAddr)
+	if ip == nil {
+		return ""
+	}
+
+	if !admin.isTrustedProxy(ip) {
+		return ip.String()
+	}
+
+	if forwardedFor := request.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		addresses := strings.Split(forwardedFor, ",")
+		for idx := len(addresses) - 1; idx >= 0; idx-- {
+			forwardedIP := parseIP(addresses[idx])
+			if forwardedIP == nil {
+				break
+			}
+
+			if ip = forwardedIP; !admin.isTrustedProxy(ip) {
+				break
+			}
+		}
+	} else if realIP := parseIP(request.Header.Get("X-Real-Ip")); realIP != nil {
+		ip = realIP
+	}
+	return ip.String()
+}
+
+// isTrustedProxy check the IP address is one of TrustedProxies or not
+func (admin *Admin) isTrustedProxy(ip net.IP) bool {
+	for _, proxy := range admin.TrustedProxies {
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if proxyIP := net.ParseIP(proxy); proxyIP != nil && proxyIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseIP(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	return net.ParseIP(address)
+}
+
+// auditLogURL url of audit logs of the record, return blank if audit log isn't enabled or not permitted
+func (context *Context) auditLogURL(record interface{}, resources ...*Resource) string {
+	res := context.getResource(resources...)
+	auditLog := context.Admin.GetResource(AuditLogResourceName)
+	if res == nil || auditLog == nil || res == auditLog || !auditLog.HasPermission(roles.Read, context.Context) {
+		return ""
+	}
+
+	query := url.Values{}
+	query.Set("filters[ResourceName].Value", res.ToParam())
+	query.Set("filters[ResourceID].Value", res.PrimaryValueOf(record))
+	return context.URLFor(auditLog) + "?" + query.Encode()
+}
+
+func isNewRecord(record interface{}) bool {
+	schema, err := gorm.Parse(record)
+	if err != nil {
+		return false
+	}
+
+	for _, field := range schema.PrimaryFields {
+		if _, zero := field.ValueOf(reflect.ValueOf(record)); !zero {
+			return false
+		}
+	}
+	return true
+}
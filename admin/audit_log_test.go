@@ -0,0 +1,135 @@
+package admin_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/roles"
+)
+
+func TestAuditLog(t *testing.T) {
+	auditDB := openTestDB(t, "audit_log")
+	auditDB.AutoMigrate(&Coupon{})
+
+	roles.Register(admin.AuditorRole, func(req *http.Request, user interface{}) bool { return req.Header.Get("X-Auditor") != "" })
+	defer roles.Remove(admin.AuditorRole)
+
+	adm := admin.New(&admin.AdminConfig{DB: auditDB, AuditLog: true, TrustedProxies: []string{"127.0.0.0/8", "10.0.0.2"}})
+	coupons := adm.AddResource(&Coupon{})
+	coupons.Action(&admin.Action{
+		Name: "Double",
+		Handler: func(argument *admin.ActionArgument) error {
+			for _, record := range argument.FindSelectedRecords() {
+				argument.Context.GetDB().Model(record).Update("discount", record.(*Coupon).Discount*2)
+			}
+			return nil
+		},
+	})
+	coupons.AddHook(resource.AfterSave, &resource.Hook{
+		Name: "reject",
+		Handler: func(record interface{}, context *qor.Context) error {
+			if record.(*Coupon).Code == "X" {
+				return errors.New("rejected")
+			}
+			return nil
+		},
+	})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	request := func(method, path string, form url.Values, headers ...string) *http.Response {
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("User-Agent", "audit-test")
+		req.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+		for _, header := range headers {
+			req.Header.Set(header, "true")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	request("POST", "/admin/coupons", url.Values{"QorResource.Code": {"A"}, "QorResource.Discount": {"5"}})
+	request("POST", "/admin/coupons", url.Values{"QorResource.Code": {"B"}, "QorResource.Discount": {"8"}})
+
+	var coupon Coupon
+	auditDB.First(&coupon, "code = ?", "A")
+	request("POST", fmt.Sprintf("/admin/coupons/%v", coupon.ID), url.Values{"QorResource.Code": {"A"}, "QorResource.Discount": {"10"}})
+	request("PUT", "/admin/coupons/!action/double", url.Values{"primary_values[]": {fmt.Sprint(coupon.ID)}})
+	request("DELETE", fmt.Sprintf("/admin/coupons/%v", coupon.ID), nil)
+
+	var logs []admin.QorAuditLog
+	auditDB.Where("resource_name = ? AND resource_id = ?", "coupons", fmt.Sprint(coupon.ID)).Order("id").Find(&logs)
+	var actions []string
+	for _, log := range logs {
+		actions = append(actions, log.Action)
+		if log.IPAddress != "10.0.0.1" || log.UserAgent != "audit-test" {
+			t.Errorf("request's IP address and user agent should be logged, but got %v, %v", log.IPAddress, log.UserAgent)
+		}
+	}
+
+	if strings.Join(actions, ",") != "create,update,Double,delete" {
+		t.Fatalf("create, update, action and delete should be logged, but got %v", actions)
+	}
+
+	if change := logs[1].GetChanges().Get("Discount"); change == nil || fmt.Sprint(change.OldValue) != "5" || fmt.Sprint(change.NewValue) != "10" {
+		t.Errorf("before and after values should be logged, but got %v", logs[1].Changes)
+	}
+
+	if change := logs[3].GetChanges().Get("Code"); change == nil || change.OldValue != "A" {
+		t.Errorf("values of deleted record should be logged, but got %v", logs[3].Changes)
+	}
+
+	var count int64
+	request("POST", "/admin/coupons", url.Values{"QorResource.Code": {"X"}})
+	if auditDB.Model(&admin.QorAuditLog{}).Where("action = ? AND changes LIKE ?", "create", "%X%").Count(&count); count != 0 {
+		t.Errorf("audit log should be rollbacked with the failed change")
+	}
+
+	auditDB.Model(&admin.QorAuditLog{}).Count(&count)
+	if resp := request("POST", "/admin/audit_logs", url.Values{"QorResource.Action": {"create"}}, "X-Auditor"); resp.StatusCode == http.StatusCreated {
+		t.Errorf("audit logs should be read-only")
+	}
+
+	if resp := request("GET", "/admin/audit_logs", nil); resp.StatusCode == http.StatusOK {
+		t.Errorf("audit logs should be denied for users other than auditors")
+	}
+
+	var total int64
+	if auditDB.Model(&admin.QorAuditLog{}).Count(&total); total != count {
+		t.Errorf("audit logs shouldn't be created from admin interface")
+	}
+
+	var couponB Coupon
+	auditDB.First(&couponB, "code = ?", "B")
+	resp := request("GET", fmt.Sprintf("/admin/coupons/%v", couponB.ID), nil, "X-Auditor")
+	body, _ := ioutil.ReadAll(resp.Body)
+	auditLogURL := fmt.Sprintf("/admin/audit_logs?filters%%5BResourceID%%5D.Value=%v&amp;filters%%5BResourceName%%5D.Value=coupons", couponB.ID)
+	if !strings.Contains(string(body), auditLogURL) {
+		t.Fatalf("audit log link should be shown in show page, but got %v", string(body))
+	}
+
+	resp = request("GET", strings.Replace(auditLogURL, "&amp;", "&", -1), nil, "X-Auditor")
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "Code: - =&gt; B") || strings.Contains(string(body), "Discount: 5 =&gt; 10") {
+		t.Errorf("audit logs should be filtered by record, but got %v", string(body))
+	}
+
+	adm.TrustedProxies = nil
+	request("POST", "/admin/coupons", url.Values{"QorResource.Code": {"C"}})
+	var log admin.QorAuditLog
+	if auditDB.Where("action = ?", "create").Order("id desc").First(&log); log.IPAddress != "127.0.0.1" {
+		t.Errorf("forwarded IP address shouldn't be trusted without trusted proxies, but got %v", log.IPAddress)
+	}
+}
@@ -30,7 +30,7 @@ type Context struct {
 
 // NewContext new admin context
 func (admin *Admin) NewContext(w http.ResponseWriter, r *http.Request) *Context {
-	return &Context{Context: &qor.Context{Config: &qor.Config{DB: admin.DB}, Request: r, Writer: w}, Admin: admin, Settings: map[string]interface{}{}}
+	return &Context{Context: &qor.Context{Config: &qor.Config{DB: admin.DB, TenantResolver: admin.TenantResolver, DBResolver: admin.DBResolver}, Request: r, Writer: w}, Admin: admin, Settings: map[string]interface{}{}}
 }
 
 // Funcs register FuncMap for templates
@@ -141,6 +141,7 @@ func (context *Context) resourcePath() string {
 func (context *Context) setResource(res *Resource) *Context {
 	if res != nil {
 		context.Resource = res
+		context.Context = res.ContextWithDB(context.Context)
 		context.ResourceID = res.GetPrimaryValue(context.Request)
 	}
 	context.Searcher = &Searcher{Context: context}
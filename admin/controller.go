@@ -2,6 +2,7 @@ package admin
 
 import (
 	"crypto/md5"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"mime"
@@ -13,7 +14,9 @@ import (
 	"time"
 
 	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor/resource"
 	"github.com/saitofun/qor/responder"
+	"github.com/saitofun/qor/roles"
 )
 
 // Controller admin controller
@@ -52,6 +55,12 @@ func (ac *Controller) SearchCenter(context *Context) {
 	var searchResults []Result
 
 	for _, res := range context.GetSearchableResources() {
+		// stop searching left resources if the request has been cancelled
+		if err := context.GetContext().Err(); err != nil {
+			context.AddError(err)
+			break
+		}
+
 		var (
 			resourceName = context.Request.URL.Query().Get("resource_name")
 			ctx          = context.clone().setResource(res)
@@ -76,22 +85,51 @@ func (ac *Controller) Create(context *Context) {
 	res := context.Resource
 	status := http.StatusCreated
 	result := res.NewStruct()
-	if context.AddError(res.Decode(context.Context, result)); !context.HasError() {
-		schema, _ := gorm.Parse(result)
-		pf := schema.PrioritizedPrimaryField
-		if _, zero := pf.ValueOf(reflect.ValueOf(result)); !zero {
-			reflect.ValueOf(result).Elem().FieldByName(pf.Name).Set(reflect.Zero(pf.FieldType))
+
+	if context.DryRun {
+		status = http.StatusOK
+	}
+
+	// setters might change database when decoding, rollback them if failed to save or in dry run mode
+	err := context.Transaction(func(*gorm.DB) error {
+		if context.AddError(res.Decode(context.Context, result)); !context.HasError() {
+			schema, _ := gorm.Parse(result)
+			pf := schema.PrioritizedPrimaryField
+			if _, zero := pf.ValueOf(reflect.ValueOf(result)); !zero {
+				reflect.ValueOf(result).Elem().FieldByName(pf.Name).Set(reflect.Zero(pf.FieldType))
+			}
+
+			// changes are removed after saved, keep them for the response
+			changeSet := resource.GetChangeSet(context.Context, result)
+			context.AddError(res.CallSave(result, context.Context))
+			resource.SetChangeSet(context.Context, result, changeSet)
+		}
+
+		if context.HasError() || context.DryRun {
+			return errSaveRollback
 		}
-		context.AddError(res.CallSave(result, context.Context))
+		return nil
+	})
+
+	if !errors.Is(err, errSaveRollback) {
+		context.AddError(err)
 	}
 
 	if context.HasError() {
+		status = statusOfErrors(context.GetErrors(), HTTPUnprocessableEntity)
 		responder.With("html", func() {
-			context.Writer.WriteHeader(HTTPUnprocessableEntity)
+			context.Writer.WriteHeader(status)
 			context.Execute("new", result)
 		}).With([]string{"json", "xml"}, func() {
-			context.Writer.WriteHeader(HTTPUnprocessableEntity)
-			context.Encode("index", map[string]interface{}{"errors": context.GetErrors()})
+			context.Writer.WriteHeader(status)
+			context.Encode("index", context.Errors)
+		}).Respond(context.Request)
+	} else if context.DryRun {
+		responder.With("html", func() {
+			context.Execute("new", result)
+		}).With([]string{"json", "xml"}, func() {
+			context.Writer.WriteHeader(status)
+			context.Encode("show", result)
 		}).Respond(context.Request)
 	} else {
 		responder.With("html", func() {
@@ -124,12 +162,18 @@ func (ac *Controller) renderSingleton(context *Context) (interface{}, bool, erro
 
 	if err == gorm.ErrRecordNotFound {
 		context.Writer.WriteHeader(http.StatusNotFound)
+	} else if errors.Is(err, roles.ErrPermissionDenied) {
+		context.Writer.WriteHeader(http.StatusForbidden)
 	}
 	return result, false, err
 }
 
-// Show render show page
+// Show render show page, soft deleted records could be shown as well
 func (ac *Controller) Show(context *Context) {
+	if context.Resource.GetSoftDeleteField() != nil {
+		context.SetDB(context.GetDB().Unscoped())
+	}
+
 	result, rendered, err := ac.renderSingleton(context)
 	if rendered {
 		return
@@ -144,6 +188,31 @@ func (ac *Controller) Show(context *Context) {
 	}).Respond(context.Request)
 }
 
+// Schema render JSON schema of resource, schemas of payloads could be got with param `mode`, e.g: `?mode=create`
+func (ac *Controller) Schema(context *Context) {
+	mode := roles.Read
+	switch context.Request.URL.Query().Get("mode") {
+	case "create":
+		mode = roles.Create
+	case "update":
+		mode = roles.Update
+	}
+
+	if !context.Resource.HasPermission(mode, context.Context) {
+		http.NotFound(context.Writer, context.Request)
+		return
+	}
+
+	js, err := json.MarshalIndent(context.Resource.JSONSchema(mode, context.Context), "", "\t")
+	if err != nil {
+		http.Error(context.Writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	context.Writer.Header().Set("Content-Type", "application/schema+json")
+	context.Writer.Write(js)
+}
+
 // Edit render edit page
 func (ac *Controller) Edit(context *Context) {
 	result, rendered, err := ac.renderSingleton(context)
@@ -175,26 +244,43 @@ func (ac *Controller) Update(context *Context) {
 
 	res := context.Resource
 	if !context.HasError() {
-		originalDB := context.DB
-		tx := context.DB.Begin()
-		context.SetDB(tx)
-		if context.AddError(res.Decode(context.Context, result)); !context.HasError() {
-			context.AddError(res.CallSave(result, context.Context))
-		}
-		if context.HasError() {
-			tx.Rollback()
-		} else {
-			tx.Commit()
+		err = context.Transaction(func(*gorm.DB) error {
+			if context.AddError(res.Decode(context.Context, result)); !context.HasError() {
+				// changes are removed after saved, keep them for the response
+				changeSet := resource.GetChangeSet(context.Context, result)
+				context.AddError(res.CallSave(result, context.Context))
+				resource.SetChangeSet(context.Context, result, changeSet)
+			}
+
+			if context.HasError() || context.DryRun {
+				return errSaveRollback
+			}
+			return nil
+		})
+
+		if !errors.Is(err, errSaveRollback) {
+			context.AddError(err)
 		}
-		context.SetDB(originalDB)
 	}
 
 	if context.HasError() {
-		context.Writer.WriteHeader(HTTPUnprocessableEntity)
+		status := statusOfErrors(context.GetErrors(), HTTPUnprocessableEntity)
+		if status == http.StatusConflict {
+			// record has been changed by someone else since it was loaded
+			context.Flash(string(context.t("qor_admin.form.conflict", "{{.Name}} has been changed by someone else, please reload it and apply your changes again", res)), "error")
+		}
+
+		context.Writer.WriteHeader(status)
+		responder.With("html", func() {
+			context.Execute("edit", result)
+		}).With([]string{"json", "xml"}, func() {
+			context.Encode("edit", context.Errors)
+		}).Respond(context.Request)
+	} else if context.DryRun {
 		responder.With("html", func() {
 			context.Execute("edit", result)
 		}).With([]string{"json", "xml"}, func() {
-			context.Encode("edit", map[string]interface{}{"errors": context.GetErrors()})
+			context.Encode("show", result)
 		}).Respond(context.Request)
 	} else {
 		responder.With("html", func() {
@@ -206,6 +292,27 @@ func (ac *Controller) Update(context *Context) {
 	}
 }
 
+// errSaveRollback used to rollback transactions of saving records if there are errors or in dry run mode
+var errSaveRollback = errors.New("qor: rollback saving record")
+
+// statusOfErrors return http status for errors, e.g: 403 for records not permitted, 409 for conflict records, 415 for unsupported media types
+func statusOfErrors(errs []error, defaultStatus int) int {
+	for _, err := range errs {
+		if errors.Is(err, roles.ErrPermissionDenied) {
+			return http.StatusForbidden
+		}
+
+		if errors.Is(err, resource.ErrConflict) {
+			return http.StatusConflict
+		}
+
+		if errors.Is(err, resource.ErrUnsupportedMediaType) {
+			return http.StatusUnsupportedMediaType
+		}
+	}
+	return defaultStatus
+}
+
 // Delete delete data
 func (ac *Controller) Delete(context *Context) {
 	res := context.Resource
@@ -213,7 +320,7 @@ func (ac *Controller) Delete(context *Context) {
 
 	if context.AddError(res.CallDelete(res.NewStruct(), context.Context)); context.HasError() {
 		context.Flash(string(context.t("qor_admin.form.failed_to_delete", "Failed to delete {{.Name}}", res)), "error")
-		status = http.StatusNotFound
+		status = statusOfErrors(context.GetErrors(), http.StatusNotFound)
 	}
 
 	responder.With("html", func() {
@@ -245,7 +352,11 @@ func (ac *Controller) Action(context *Context) {
 			actionArgument.Argument = result
 		}
 
-		context.AddError(action.Handler(&actionArgument))
+		if ac.Admin.AuditLog {
+			context.AddError(context.Resource.handleActionWithAuditLog(action, &actionArgument))
+		} else {
+			context.AddError(action.Handler(&actionArgument))
+		}
 
 		if !actionArgument.SkipDefaultResponse {
 			if !context.HasError() {
@@ -261,11 +372,7 @@ func (ac *Controller) Action(context *Context) {
 				responder.With("html", func() {
 					context.Execute("action", action)
 				}).With([]string{"json", "xml"}, func() {
-					var errs []string
-					for _, err := range context.GetErrors() {
-						errs = append(errs, err.Error())
-					}
-					context.Encode("OK", map[string]interface{}{"errors": errs, "status": "error"})
+					context.Encode("OK", map[string]interface{}{"errors": context.GetFieldErrors(), "status": "error"})
 				}).Respond(context.Request)
 			}
 		}
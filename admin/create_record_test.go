@@ -5,16 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/saitofun/qor/admin"
 	. "github.com/saitofun/qor/admin/tests/dummy"
 	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/validations"
 )
 
 func TestCreateRecord(t *testing.T) {
@@ -277,3 +283,176 @@ func TestCreateRecordWithJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateHasManyRecordWithNestedError(t *testing.T) {
+	addressRes := Admin.GetResource("User").GetMeta("Addresses").Resource
+	validators := addressRes.Validators
+	defer func() { addressRes.Validators = validators }()
+
+	addressRes.AddValidator(&resource.Validator{
+		Name: "address1",
+		Handler: func(record interface{}, metaValues *resource.MetaValues, context *qor.Context) error {
+			if address1 := metaValues.Get("Address1"); address1 != nil && address1.Value == "invalid" {
+				return validations.NewErrorWithCode(record, "Address1", "invalid_address", "invalid address")
+			}
+			return nil
+		},
+	})
+
+	name := "create_record_and_has_many_with_nested_error"
+	json := `{"Name": "create_record_and_has_many_with_nested_error", "Addresses": [{"Address1": "address_1"}, {"Address1": "invalid"}]}`
+
+	if req, err := http.Post(server.URL+"/admin/users.json", "application/json", strings.NewReader(json)); err == nil {
+		if req.StatusCode != 422 {
+			t.Errorf("Create request should be failed with nested error, but got status %v", req.StatusCode)
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		if !strings.Contains(string(body), `"path": "Addresses[1].Address1"`) || !strings.Contains(string(body), `"code": "invalid_address"`) {
+			t.Errorf("Nested error should be addressed to its row, but got %v", string(body))
+		}
+
+		if !errors.Is(db.First(&User{}, "name = ?", name).Error, gorm.ErrRecordNotFound) {
+			t.Errorf("User should not be created with invalid addresses")
+		}
+	} else {
+		t.Errorf(err.Error())
+	}
+}
+
+func TestCreateRecordsFromCSV(t *testing.T) {
+	csv := "name,Role,CreditCard.Number,Addresses[0].Address1,Addresses[1].Address1\n" +
+		"create_records_from_csv_1,admin,1234567890,address_1,address_2\n" +
+		"create_records_from_csv_2,admin,,,\n"
+
+	var (
+		res     = Admin.GetResource("User")
+		context = &qor.Context{DB: db}
+	)
+
+	rows, err := resource.ConvertCSVToMetaValues(strings.NewReader(csv), res.GetMetas([]string{}))
+	if err != nil {
+		t.Fatalf("no error should happen when convert csv, but got %v", err)
+	}
+
+	users, err := resource.DecodeRowsToResource(res, rows, context)
+	if err != nil {
+		t.Fatalf("no error should happen when decode rows, but got %v", err)
+	}
+
+	if err := res.CallSaveMany(users, context); err != nil {
+		t.Fatalf("no error should happen when save users, but got %v", err)
+	}
+
+	var user User
+	if err := db.Preload("CreditCard").Preload("Addresses").First(&user, "name = ?", "create_records_from_csv_1").Error; err != nil {
+		t.Fatalf("user should be created from csv, but got %v", err)
+	}
+
+	if user.CreditCard.Number != "1234567890" || len(user.Addresses) != 2 {
+		t.Errorf("nested records should be created from csv, but got %v, %v", user.CreditCard.Number, len(user.Addresses))
+	}
+
+	if err := db.First(&User{}, "name = ?", "create_records_from_csv_2").Error; err != nil {
+		t.Errorf("user should be created from csv, but got %v", err)
+	}
+}
+
+func TestCreateRecordWithRegisteredDecoder(t *testing.T) {
+	name := "create_record_with_registered_decoder"
+
+	if req, err := http.Post(server.URL+"/admin/users.json", "application/x-name", strings.NewReader(name)); err == nil {
+		if req.StatusCode != http.StatusUnsupportedMediaType {
+			t.Errorf("Create request with unsupported media type should get status 415, but got %v", req.StatusCode)
+		}
+	} else {
+		t.Errorf(err.Error())
+	}
+
+	resource.RegisterDecoder("application/x-name", func(context *qor.Context, result interface{}, metaors []resource.Metaor) (*resource.MetaValues, error) {
+		body, err := ioutil.ReadAll(context.Request.Body)
+		var meta resource.Metaor
+		for _, m := range metaors {
+			if m.GetName() == "Name" {
+				meta = m
+			}
+		}
+		return &resource.MetaValues{Values: []*resource.MetaValue{{Name: "Name", Value: string(body), Meta: meta}}}, err
+	})
+
+	if req, err := http.Post(server.URL+"/admin/users.json", "application/x-name; charset=utf-8", strings.NewReader(name)); err == nil {
+		if req.StatusCode != http.StatusCreated {
+			t.Errorf("Create request should be processed with registered decoder, but got status %v", req.StatusCode)
+		}
+
+		if errors.Is(db.First(&User{}, "name = ?", name).Error, gorm.ErrRecordNotFound) {
+			t.Errorf("User should be created with registered decoder")
+		}
+	} else {
+		t.Errorf(err.Error())
+	}
+}
+
+func TestCreateRecordWithDryRun(t *testing.T) {
+	name := "create_record_with_dry_run"
+	number := name + "_card"
+	json := fmt.Sprintf(`{"Name": "%v", "Role": "admin", "CreditCard": {"Number": "%v"}}`, name, number)
+
+	if req, err := http.Post(server.URL+"/admin/users.json?dry_run=1", "application/json", strings.NewReader(json)); err == nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		if req.StatusCode != http.StatusOK {
+			t.Errorf("Dry run request should be processed successfully, but got status %v", req.StatusCode)
+		}
+
+		if !strings.Contains(string(body), name) {
+			t.Errorf("Would-be record should be returned, but got %v", string(body))
+		}
+
+		if !errors.Is(db.First(&User{}, "name = ?", name).Error, gorm.ErrRecordNotFound) {
+			t.Errorf("User should not be created in dry run mode")
+		}
+
+		if !errors.Is(db.First(&CreditCard{}, "number = ?", number).Error, gorm.ErrRecordNotFound) {
+			t.Errorf("Credit card should not be created in dry run mode")
+		}
+	} else {
+		t.Errorf(err.Error())
+	}
+}
+
+type Playlist struct {
+	gorm.Model
+	Name  string
+	Songs []Song
+}
+
+type Song struct {
+	gorm.Model
+	PlaylistID uint
+	Name       string
+	Length     int
+}
+
+func TestCreateHasManyRecordWithNestedSetterError(t *testing.T) {
+	playlistDB := openTestDB(t, "playlists")
+	playlistDB.AutoMigrate(&Playlist{}, &Song{})
+
+	adm := admin.New(&admin.AdminConfig{DB: playlistDB})
+	adm.AddResource(&Playlist{})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	json := `{"Name": "nested_setter_error", "Songs": [{"Name": "one", "Length": "60"}, {"Name": "two", "Length": "abc"}]}`
+	if req, err := http.Post(server.URL+"/admin/playlists.json", "application/json", strings.NewReader(json)); err == nil {
+		if req.StatusCode != 422 {
+			t.Errorf("Create request should be failed with nested setter error, but got status %v", req.StatusCode)
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		if !strings.Contains(string(body), `"path": "Songs[1].Length"`) {
+			t.Errorf("Nested setter error should be addressed to its row, but got %v", string(body))
+		}
+	} else {
+		t.Errorf(err.Error())
+	}
+}
@@ -0,0 +1,110 @@
+package admin_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"gorm.io/driver/sqlite"
+)
+
+type Visit struct {
+	gorm.Model
+	Path string
+}
+
+func openTestDB(t *testing.T, name string) *gorm.DB {
+	testDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name)), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testDB.AutoMigrate(&Visit{})
+	return testDB
+}
+
+func TestResourceWithDB(t *testing.T) {
+	analytics := openTestDB(t, "analytics")
+	db.Migrator().DropTable(&Visit{})
+	db.AutoMigrate(&Visit{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	adm.AddResource(&Visit{}, &admin.Config{DB: analytics})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/admin/visits.json", "application/json", strings.NewReader(`{"Path": "/products"}`))
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("visit should be created, but got %v", err)
+	}
+
+	var count int64
+	if analytics.Model(&Visit{}).Where("path = ?", "/products").Count(&count); count != 1 {
+		t.Errorf("visit should be saved into resource's db")
+	}
+
+	if db.Model(&Visit{}).Count(&count); count != 0 {
+		t.Errorf("visit shouldn't be saved into admin's db")
+	}
+
+	resp, _ = http.Get(server.URL + "/admin/visits.json")
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "/products") {
+		t.Errorf("visits should be listed from resource's db, but got %v", string(body))
+	}
+}
+
+func TestResourceWithReadReplica(t *testing.T) {
+	primary, replica := openTestDB(t, "primary"), openTestDB(t, "replica")
+
+	visit := Visit{Path: "/primary"}
+	primary.Create(&visit)
+	replica.Create(&Visit{Path: "/replica"})
+
+	adm := admin.New(&qor.Config{DB: db})
+	adm.AddResource(&Visit{}, &admin.Config{DB: primary, DBResolver: qor.ReadReplicas(replica)})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	resp, _ := http.Get(server.URL + "/admin/visits.json")
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "/replica") || strings.Contains(string(body), "/primary") {
+		t.Errorf("visits should be listed from replica, but got %v", string(body))
+	}
+
+	resp, _ = http.Get(fmt.Sprintf("%v/admin/visits/%v.json", server.URL, visit.ID))
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "/primary") {
+		t.Errorf("visit should be found from primary, but got %v", string(body))
+	}
+}
+
+func TestResourceWithDBSavesAdminTablesIntoAdminDB(t *testing.T) {
+	adminDB, analytics := openTestDB(t, "admin"), openTestDB(t, "analytics")
+
+	adm := admin.New(&admin.AdminConfig{DB: adminDB, AuditLog: true, Auth: settingAuth{}})
+	adm.AddResource(&Visit{}, &admin.Config{DB: analytics, Versioning: true})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	req, _ := http.NewRequest("POST", server.URL+"/admin/visits.json", strings.NewReader(`{"Path": "/products"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User", "alice")
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("visit should be created, but got %v", err)
+	}
+
+	var versions, logs int64
+	adminDB.Model(&admin.QorVersion{}).Where("resource_name = ?", "visits").Count(&versions)
+	adminDB.Model(&admin.QorAuditLog{}).Where("resource_name = ? AND user_name = ?", "visits", "alice").Count(&logs)
+	if versions != 1 || logs != 1 {
+		t.Errorf("versions and audit logs with current user should be saved into admin's db, but got %v versions, %v logs", versions, logs)
+	}
+
+	if analytics.Migrator().HasTable(&admin.QorVersion{}) || analytics.Migrator().HasTable(&admin.QorAuditLog{}) {
+		t.Errorf("admin tables shouldn't be migrated into resource's db")
+	}
+}
@@ -3,12 +3,18 @@ package admin_test
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/saitofun/qor/admin"
 	. "github.com/saitofun/qor/admin/tests/dummy"
 	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
 )
 
 func TestDeleteRecord(t *testing.T) {
@@ -30,3 +36,74 @@ func TestDeleteRecord(t *testing.T) {
 		t.Errorf(err.Error())
 	}
 }
+
+func TestTrashRecord(t *testing.T) {
+	type TrashedPage struct {
+		gorm.Model
+		Title string
+	}
+
+	db.Migrator().DropTable(&TrashedPage{})
+	db.AutoMigrate(&TrashedPage{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	res := adm.AddResource(&TrashedPage{})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	page1, page2 := TrashedPage{Title: "page1"}, TrashedPage{Title: "page2"}
+	db.Save(&page1)
+	db.Save(&page2)
+
+	request := func(method, path string) (*http.Response, string) {
+		req, _ := http.NewRequest(method, server.URL+"/admin/trashed_pages"+path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	if resp, _ := request("DELETE", fmt.Sprintf("/%v.json", page1.ID)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("page should be deleted, but got status %v", resp.StatusCode)
+	}
+
+	if _, body := request("GET", ".json"); strings.Contains(body, "page1") || !strings.Contains(body, "page2") {
+		t.Errorf("deleted pages should be excluded from index, but got %v", body)
+	}
+
+	if _, body := request("GET", ".json?scopes=Trash"); !strings.Contains(body, "page1") || strings.Contains(body, "page2") {
+		t.Errorf("only deleted pages should be listed in trash, but got %v", body)
+	}
+
+	if resp, body := request("GET", fmt.Sprintf("/%v.json", page1.ID)); resp.StatusCode != http.StatusOK || !strings.Contains(body, "_deleted_at") {
+		t.Errorf("deleted page should be shown as deleted, but got %v, %v", resp.StatusCode, body)
+	}
+
+	if _, body := request("GET", fmt.Sprintf("/%v", page1.ID)); !strings.Contains(body, "has been deleted") {
+		t.Errorf("show page should say the page has been deleted")
+	}
+
+	// restore and purge are denied unless they are allowed explicitly
+	if resp, _ := request("PUT", fmt.Sprintf("/%v/restore.json", page1.ID)); resp.StatusCode == http.StatusOK {
+		t.Errorf("page should not be restored without restore permission")
+	}
+
+	res.Permission = roles.Allow(roles.CRUD, roles.Anyone).Allow(roles.Restore, roles.Anyone).Allow(roles.Purge, roles.Anyone)
+	if resp, _ := request("PUT", fmt.Sprintf("/%v/restore.json", page1.ID)); resp.StatusCode != http.StatusOK {
+		t.Errorf("page should be restored, but got status %v", resp.StatusCode)
+	}
+
+	if err := db.First(&TrashedPage{}, page1.ID).Error; err != nil {
+		t.Errorf("restored page should be found, but got %v", err)
+	}
+
+	if resp, _ := request("PUT", fmt.Sprintf("/%v/delete_permanently.json", page2.ID)); resp.StatusCode != http.StatusOK {
+		t.Errorf("page should be deleted permanently, but got status %v", resp.StatusCode)
+	}
+
+	if err := db.Unscoped().First(&TrashedPage{}, page2.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("page should be deleted permanently, but got %v", err)
+	}
+}
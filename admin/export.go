@@ -0,0 +1,376 @@
+package admin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/roles"
+	"gorm.io/gorm/clause"
+)
+
+// ExportBatchSize records count of each batch when exporting records
+var ExportBatchSize = 500
+
+// ExportWriter writer of exported rows, rows are flushed after each batch, so they could be streamed to clients
+type ExportWriter interface {
+	WriteRow(values []string) error
+	Flush() error
+	Close() error
+}
+
+// ExportFormat format of exported files
+type ExportFormat struct {
+	ContentType string
+	NewWriter   func(w io.Writer) ExportWriter
+}
+
+// ExportFormats registered export formats, key is the file extension, e.g: `/admin/products/!export.csv`
+var ExportFormats = map[string]*ExportFormat{
+	"csv": {
+		ContentType: "text/csv; charset=utf-8",
+		NewWriter: func(w io.Writer) ExportWriter {
+			return &csvExportWriter{Writer: csv.NewWriter(w)}
+		},
+	},
+	"xlsx": {
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		NewWriter: func(w io.Writer) ExportWriter {
+			return newXLSXExportWriter(w)
+		},
+	},
+}
+
+// FindInBatches find records based on current conditions and orders without pagination, records are passed to fc in batches, so they won't be loaded into memory at once,
+// primary keys are appended to orders to make them unique, latest records come first if there are no orders. batches are paginated with values of ordered columns
+// instead of offsets if all orders are columns that couldn't be null, so records won't be skipped or duplicated when records are changed during finding
+func (s *Searcher) FindInBatches(batchSize int, fc func(results interface{}) error) error {
+	searcher := s.clone()
+	searcher.Pagination.CurrentPage = -1
+
+	context := searcher.parseContext(true)
+	if context.HasError() {
+		return context.Errors
+	}
+
+	var (
+		db             = context.GetDB().Session(&gorm.Session{Context: context.GetContext()})
+		schema, _      = gorm.Parse(s.Resource.Value)
+		orders, keyset = batchOrders(db, schema, s.Resource.PrimaryFields)
+		lastValues     []interface{}
+		offset         int
+	)
+
+	// orders are rebuilt with parsed columns for keyset pagination, otherwise primary keys are appended to current orders
+	if keyset {
+		delete(db.Statement.Clauses, "ORDER BY")
+	}
+
+	for _, order := range orders {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: order.Column, Raw: true}, Desc: order.Desc})
+	}
+
+	for {
+		batchDB := db.Limit(batchSize)
+		if !keyset {
+			batchDB = batchDB.Offset(offset)
+		} else if lastValues != nil {
+			sql, values := keysetCondition(orders, lastValues)
+			batchDB = batchDB.Where(sql, values...)
+		}
+
+		batchContext := context.Clone()
+		batchContext.SetDB(batchDB)
+
+		results := s.Resource.NewSlice()
+		if err := s.Resource.CallFindMany(results, batchContext); err != nil {
+			return err
+		}
+
+		records := reflect.Indirect(reflect.ValueOf(results))
+		count := records.Len()
+		if count == 0 {
+			return nil
+		}
+
+		if err := fc(results); err != nil {
+			return err
+		}
+
+		if count < batchSize {
+			return nil
+		}
+
+		offset += count
+		lastValues = nil
+		for _, order := range orders {
+			value, _ := order.Field.ValueOf(reflect.ValueOf(records.Index(count - 1).Interface()))
+			lastValues = append(lastValues, value)
+		}
+	}
+}
+
+// batchOrder order of records that found in batches
+type batchOrder struct {
+	Column string
+	Field  *gorm.Field
+	Desc   bool
+}
+
+// batchOrderRegexp regexp of order that sort records by a column, e.g: `name`, `products.name DESC`
+var batchOrderRegexp = regexp.MustCompile("(?i)^\\s*(?:[`\"]?(\\w+)[`\"]?\\.)?[`\"]?(\\w+)[`\"]?(?:\\s+(ASC|DESC))?\\s*$")
+
+// batchOrders parse orders of db, primary fields are appended if they are not ordered yet, it returns false and primary fields only
+// if keyset pagination couldn't be used as some orders are expressions or columns that could be null
+func batchOrders(db *gorm.DB, schema *gorm.Schema, primaryFields []*gorm.Field) (orders []batchOrder, keyset bool) {
+	keyset = true
+	addOrder := func(field *gorm.Field, desc bool) {
+		for _, order := range orders {
+			if order.Field == field {
+				return
+			}
+		}
+		orders = append(orders, batchOrder{Column: fmt.Sprintf("%v.%v", db.Statement.Quote(schema.Table), db.Statement.Quote(field.DBName)), Field: field, Desc: desc})
+	}
+
+	if c, ok := db.Statement.Clauses["ORDER BY"]; ok {
+		orderBy, _ := c.Expression.(clause.OrderBy)
+		if orderBy.Expression != nil {
+			keyset = false
+		}
+
+		for _, column := range orderBy.Columns {
+			names := []string{column.Column.Name}
+			if column.Column.Raw {
+				names = strings.Split(column.Column.Name, ",")
+			}
+
+			for _, name := range names {
+				table, desc := column.Column.Table, column.Desc
+				if column.Column.Raw {
+					matches := batchOrderRegexp.FindStringSubmatch(name)
+					if matches == nil {
+						keyset = false
+						continue
+					}
+					table, name, desc = matches[1], matches[2], strings.EqualFold(matches[3], "DESC")
+				}
+
+				if field := schema.LookUpField(name); field != nil && (table == "" || table == schema.Table) && isNotNullField(field) {
+					addOrder(field, desc)
+				} else {
+					keyset = false
+				}
+			}
+		}
+	}
+
+	if !keyset {
+		orders = nil
+	}
+
+	for _, field := range primaryFields {
+		addOrder(field, true)
+	}
+	return
+}
+
+// isNotNullField check values of the field couldn't be null, fields of pointers or sql.Null* types are nullable unless they are declared as not null
+func isNotNullField(field *gorm.Field) bool {
+	if field.NotNull || field.PrimaryKey {
+		return true
+	}
+
+	switch field.FieldType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Struct:
+		return field.FieldType == reflect.TypeOf(time.Time{})
+	}
+	return false
+}
+
+// keysetCondition condition of records after last values in the orders, e.g: `a > ? OR (a = ? AND b < ?)` for orders `a, b DESC`
+func keysetCondition(orders []batchOrder, lastValues []interface{}) (string, []interface{}) {
+	var (
+		conditions []string
+		values     []interface{}
+	)
+
+	for idx, order := range orders {
+		var sqls []string
+		for i := 0; i < idx; i++ {
+			sqls = append(sqls, orders[i].Column+" = ?")
+			values = append(values, lastValues[i])
+		}
+
+		if order.Desc {
+			sqls = append(sqls, order.Column+" < ?")
+		} else {
+			sqls = append(sqls, order.Column+" > ?")
+		}
+		values = append(values, lastValues[idx])
+		conditions = append(conditions, "("+strings.Join(sqls, " AND ")+")")
+	}
+	return strings.Join(conditions, " OR "), values
+}
+
+// Export export records of index page with current scopes, filters, keyword and order, values of index attributes are formatted like index page
+func (context *Context) Export(w io.Writer, format *ExportFormat) error {
+	var (
+		res    = context.Resource
+		metas  = res.ConvertSectionToMetas(context.indexSections(res))
+		writer = format.NewWriter(w)
+		header []string
+	)
+
+	for _, meta := range metas {
+		header = append(header, string(context.Admin.T(context.Context, fmt.Sprintf("%v.attributes.%v", meta.baseResource.ToParam(), meta.Label), meta.Label)))
+	}
+
+	if err := writer.WriteRow(header); err != nil {
+		return err
+	}
+
+	err := context.Searcher.FindInBatches(ExportBatchSize, func(results interface{}) error {
+		values := reflect.Indirect(reflect.ValueOf(results))
+		for i := 0; i < values.Len(); i++ {
+			var row []string
+			for _, meta := range metas {
+				row = append(row, exportValue(context.FormattedValueOf(values.Index(i).Interface(), meta)))
+			}
+
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Export export records of index page, format is decided by the extension, e.g: `/admin/products/!export.xlsx`
+func (ac *Controller) Export(context *Context) {
+	formatName := context.Request.URL.Query().Get(":format")
+	if formatName == "" {
+		formatName = "csv"
+	}
+
+	format, ok := ExportFormats[formatName]
+	if !ok || !context.Resource.HasPermission(roles.Read, context.Context) {
+		http.NotFound(context.Writer, context.Request)
+		return
+	}
+
+	context.Writer.Header().Set("Content-Type", format.ContentType)
+	context.Writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(context.Resource.ToParam())+"."+formatName))
+
+	// headers have been sent when rows are streamed, so errors couldn't be responded with status code,
+	// they are logged and the response is aborted, so clients won't get a truncated file as a complete one
+	if err := context.Export(flushWriter{context.Writer}, format); err != nil {
+		log.Printf("failed to export %v: %v\n", context.Resource.ToParam(), err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// exportURL return export url of current index page with its scopes, filters, keyword and order
+func (context *Context) exportURL(res *Resource, format string) string {
+	query := url.Values{}
+	if context.Request != nil {
+		query = context.Request.URL.Query()
+	}
+
+	for key := range query {
+		if strings.HasPrefix(key, ":") {
+			query.Del(key)
+		}
+	}
+
+	for _, key := range []string{"page", "per_page"} {
+		query.Del(key)
+	}
+
+	exportURL := path.Join(context.URLFor(res), "!export."+format)
+	if len(query) > 0 {
+		exportURL += "?" + query.Encode()
+	}
+	return exportURL
+}
+
+func (context *Context) hasExportPermission(permissioner HasPermissioner) bool {
+	return permissioner.HasPermission(roles.Read, context.Context) && permissioner.HasPermission(roles.Export, context.Context)
+}
+
+func exportValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	if reflectValue := reflect.ValueOf(value); reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return ""
+		}
+		value = reflectValue.Elem().Interface()
+	}
+
+	if html, ok := value.(template.HTML); ok {
+		return string(html)
+	}
+	return fmt.Sprint(value)
+}
+
+// flushWriter flush response after writes, so exported rows are streamed to clients
+type flushWriter struct {
+	http.ResponseWriter
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+type csvExportWriter struct {
+	*csv.Writer
+}
+
+// WriteRow write row, values that could be interpreted as formulas by spreadsheets are escaped
+func (writer *csvExportWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for idx, value := range values {
+		if _, err := strconv.ParseFloat(value, 64); err != nil && value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+			value = "'" + value
+		}
+		escaped[idx] = value
+	}
+	return writer.Write(escaped)
+}
+
+func (writer *csvExportWriter) Flush() error {
+	writer.Writer.Flush()
+	return writer.Error()
+}
+
+func (writer *csvExportWriter) Close() error {
+	return writer.Flush()
+}
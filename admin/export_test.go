@@ -0,0 +1,197 @@
+package admin_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+func TestExportRecords(t *testing.T) {
+	exportDB := openTestDB(t, "export")
+	for i := 1; i <= 5; i++ {
+		exportDB.Create(&Visit{Path: fmt.Sprintf("/products/%v", i)})
+	}
+	exportDB.Create(&Visit{Path: "=cmd()"})
+
+	batchSize := admin.ExportBatchSize
+	admin.ExportBatchSize = 2
+	defer func() { admin.ExportBatchSize = batchSize }()
+
+	adm := admin.New(&qor.Config{DB: exportDB})
+	visits := adm.AddResource(&Visit{})
+	visits.IndexAttrs("ID", "Path")
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	resp, _ := http.Get(server.URL + "/admin/visits?keyword=products&page=2")
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "/admin/visits/!export.csv?keyword=products") {
+		t.Errorf("export links with current conditions should be rendered in index page")
+	}
+
+	resp, err := http.Get(server.URL + "/admin/visits/!export.csv?keyword=products&page=2")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("records should be exported, but got %v", err)
+	}
+
+	if disposition := resp.Header.Get("Content-Disposition"); !strings.Contains(disposition, "visits.csv") {
+		t.Errorf("exported file should be named visits.csv, but got %v", disposition)
+	}
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("exported csv should be valid, but got %v", err)
+	}
+
+	if len(rows) != 6 || rows[0][1] != "Path" || rows[1][1] != "/products/5" || rows[5][1] != "/products/1" {
+		t.Errorf("all records matched keyword should be exported without pagination, but got %v", rows)
+	}
+
+	resp, _ = http.Get(server.URL + "/admin/visits/!export.csv?keyword=cmd")
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "'=cmd()") {
+		t.Errorf("formulas should be escaped, but got %v", string(body))
+	}
+
+	resp, _ = http.Get(server.URL + "/admin/visits/!export.xlsx")
+	body, _ := ioutil.ReadAll(resp.Body)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("exported xlsx should be a valid zip archive, but got %v", err)
+	}
+
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, _ := file.Open()
+			if sheet, _ := ioutil.ReadAll(reader); !strings.Contains(string(sheet), "/products/3") || !strings.Contains(string(sheet), "=cmd()") {
+				t.Errorf("records should be exported into sheet, but got %v", string(sheet))
+			}
+		}
+	}
+}
+
+func TestExportInBatches(t *testing.T) {
+	exportDB := openTestDB(t, "export_batches")
+	for i := 1; i <= 5; i++ {
+		exportDB.Create(&Visit{Path: fmt.Sprintf("/products/%v", i)})
+	}
+
+	batchSize := admin.ExportBatchSize
+	admin.ExportBatchSize = 2
+	defer func() { admin.ExportBatchSize = batchSize }()
+
+	adm := admin.New(&qor.Config{DB: exportDB})
+	visits := adm.AddResource(&Visit{})
+	visits.IndexAttrs("ID", "Path")
+
+	var batches int
+	findManyHandler := visits.FindManyHandler
+	visits.FindManyHandler = func(result interface{}, context *qor.Context) error {
+		if _, ok := context.GetDB().Get("qor:getting_total_count"); !ok {
+			if batches++; batches == 2 {
+				// exported records are deleted during exporting
+				exportDB.Where("path = ?", "/products/5").Delete(&Visit{})
+			} else if batches == 3 && context.Request.URL.Query().Get("fail") != "" {
+				return errors.New("failed to find visits")
+			}
+		}
+		return findManyHandler(result, context)
+	}
+
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	resp, _ := http.Get(server.URL + "/admin/visits/!export.csv")
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil || len(rows) != 6 || rows[1][1] != "/products/5" || rows[5][1] != "/products/1" {
+		t.Errorf("records shouldn't be skipped when records are changed during exporting, but got %v, %v", rows, err)
+	}
+
+	batches = 0
+	resp, _ = http.Get(server.URL + "/admin/visits/!export.csv?fail=1")
+	if body, err := ioutil.ReadAll(resp.Body); err == nil || strings.Contains(string(body), "failed to find visits") {
+		t.Errorf("response should be aborted without errors when failed to export, but got %v", string(body))
+	}
+}
+
+func TestExportInBatchesWithOrder(t *testing.T) {
+	exportDB := openTestDB(t, "export_batches_order")
+	for _, path := range []string{"ccc", "a", "bb", "a", "ccc", "bb"} {
+		exportDB.Create(&Visit{Path: path})
+	}
+
+	batchSize := admin.ExportBatchSize
+	admin.ExportBatchSize = 2
+	defer func() { admin.ExportBatchSize = batchSize }()
+
+	adm := admin.New(&qor.Config{DB: exportDB})
+	visits := adm.AddResource(&Visit{})
+	visits.IndexAttrs("ID", "Path")
+	visits.Scope(&admin.Scope{Name: "length", Handler: func(db *gorm.DB, context *qor.Context) *gorm.DB {
+		return db.Order("LENGTH(path) DESC")
+	}})
+
+	var batches int
+	findManyHandler := visits.FindManyHandler
+	visits.FindManyHandler = func(result interface{}, context *qor.Context) error {
+		if _, ok := context.GetDB().Get("qor:getting_total_count"); !ok {
+			if batches++; batches == 2 && context.Request.URL.Query().Get("delete") != "" {
+				// exported records are deleted during exporting
+				exportDB.Delete(&Visit{}, 4)
+			}
+		}
+		return findManyHandler(result, context)
+	}
+
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	// records with same values are ordered by primary keys, latest records come first
+	for _, c := range []struct {
+		query    string
+		expected string
+	}{
+		{"scopes=length", "5,1,6,3,4,2"},
+		{"order_by=path&delete=1", "4,2,6,3,5,1"},
+		{"order_by=path_desc", "5,1,6,3,2"},
+	} {
+		batches = 0
+		resp, _ := http.Get(server.URL + "/admin/visits/!export.csv?" + c.query)
+		rows, err := csv.NewReader(resp.Body).ReadAll()
+		var ids []string
+		for _, row := range rows[1:] {
+			ids = append(ids, row[0])
+		}
+
+		if err != nil || strings.Join(ids, ",") != c.expected {
+			t.Errorf("records should be exported in order of %v, expected %v, but got %v, %v", c.query, c.expected, rows, err)
+		}
+	}
+}
+
+func TestExportPermission(t *testing.T) {
+	adm := admin.New(&qor.Config{DB: openTestDB(t, "export_permission")})
+	adm.AddResource(&Visit{}, &admin.Config{Permission: roles.Allow(roles.CRUD, roles.Anyone)})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	if resp, _ := http.Get(server.URL + "/admin/visits/!export.csv"); resp.StatusCode == http.StatusOK && strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") {
+		t.Errorf("records shouldn't be exported without export permission")
+	}
+
+	if resp, _ := http.Get(server.URL + "/admin/visits"); resp.StatusCode != http.StatusOK {
+		t.Errorf("index page should be accessible, but got %v", resp.StatusCode)
+	} else if body, _ := ioutil.ReadAll(resp.Body); strings.Contains(string(body), "!export.csv") {
+		t.Errorf("export links shouldn't be rendered without export permission")
+	}
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+)
+
+var xlsxStaticFiles = []struct{ Name, Content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// xlsxExportWriter write rows into a single sheet workbook, the sheet is written as the last file of the zip archive, so rows could be streamed
+type xlsxExportWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	err     error
+}
+
+func newXLSXExportWriter(w io.Writer) *xlsxExportWriter {
+	writer := &xlsxExportWriter{archive: zip.NewWriter(w)}
+	for _, file := range xlsxStaticFiles {
+		writer.writeFile(file.Name, file.Content)
+	}
+
+	if writer.err == nil {
+		var sheet io.Writer
+		if sheet, writer.err = writer.archive.Create("xl/worksheets/sheet1.xml"); writer.err == nil {
+			writer.sheet = bufio.NewWriter(sheet)
+			writer.writeString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+				`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+		}
+	}
+	return writer
+}
+
+func (writer *xlsxExportWriter) writeFile(name, content string) {
+	if writer.err == nil {
+		var file io.Writer
+		if file, writer.err = writer.archive.Create(name); writer.err == nil {
+			_, writer.err = io.WriteString(file, content)
+		}
+	}
+}
+
+func (writer *xlsxExportWriter) writeString(str string) {
+	if writer.err == nil {
+		_, writer.err = writer.sheet.WriteString(str)
+	}
+}
+
+// WriteRow write row with values as inline strings
+func (writer *xlsxExportWriter) WriteRow(values []string) error {
+	writer.writeString("<row>")
+	for _, value := range values {
+		writer.writeString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if writer.err == nil {
+			writer.err = xml.EscapeText(writer.sheet, []byte(value))
+		}
+		writer.writeString("</t></is></c>")
+	}
+	writer.writeString("</row>")
+	return writer.err
+}
+
+func (writer *xlsxExportWriter) Flush() error {
+	if writer.err == nil {
+		if writer.err = writer.sheet.Flush(); writer.err == nil {
+			writer.err = writer.archive.Flush()
+		}
+	}
+	return writer.err
+}
+
+func (writer *xlsxExportWriter) Close() error {
+	writer.writeString("</sheetData></worksheet>")
+	if writer.Flush() == nil {
+		writer.err = writer.archive.Close()
+	}
+	return writer.err
+}
@@ -19,10 +19,12 @@ type Filter struct {
 	Config     FilterConfigInterface
 }
 
-// SavedFilter saved filter settings
+// SavedFilter saved filter settings, shared filters are visible to users of the role that they are shared with, or all users if role is blank, others are personal ones of the user who saved them
 type SavedFilter struct {
-	Name string
-	URL  string
+	Name   string
+	URL    string
+	Shared bool
+	Role   string `json:"-"`
 }
 
 // FilterConfigInterface filter config interface
@@ -108,3 +110,57 @@ func (res *Resource) GetFilter(name string) *Filter {
 	}
 	return nil
 }
+
+// canSavePersonalFilters check current user could save personal filters or not, personal filters need current user has id if the settings storage supports layers
+func (context *Context) canSavePersonalFilters() bool {
+	if _, ok := context.Admin.SettingsStorage.(LayeredSettingsStorageInterface); ok {
+		return qor.CurrentUserID(context.CurrentUser) != ""
+	}
+	return true
+}
+
+// sharedFiltersRole role that shared filters are saved for, filters couldn't be shared if it is blank
+func (context *Context) sharedFiltersRole() string {
+	if _, ok := context.Admin.SettingsStorage.(LayeredSettingsStorageInterface); ok {
+		return context.Admin.roleSettingsOwner(context)
+	}
+	return ""
+}
+
+// loadSavedFilters load personal filters, or shared filters of the role, filters shared with all users are loaded if role is blank,
+// all saved filters will be loaded if the settings storage doesn't support layers
+func (context *Context) loadSavedFilters(shared bool, role string) (filters []SavedFilter, err error) {
+	storage, ok := context.Admin.SettingsStorage.(LayeredSettingsStorageInterface)
+	switch {
+	case !ok:
+		err = context.Admin.SettingsStorage.Get("saved_filters", &filters, context)
+	case !shared:
+		err = storage.GetLayer("saved_filters", PersonalSettingLayer, &filters, context)
+	case role != "":
+		err = storage.GetRole("saved_filters", role, &filters, context)
+	default:
+		err = storage.GetLayer("saved_filters", GlobalSettingLayer, &filters, context)
+	}
+
+	if ok {
+		for idx := range filters {
+			filters[idx].Shared, filters[idx].Role = shared, role
+		}
+	}
+	return
+}
+
+// saveSavedFilters save personal filters, or shared filters of the role, filters shared with all users are saved if role is blank
+func (context *Context) saveSavedFilters(filters []SavedFilter, shared bool, role string) error {
+	storage, ok := context.Admin.SettingsStorage.(LayeredSettingsStorageInterface)
+	switch {
+	case !ok:
+		return context.Admin.SettingsStorage.Save("saved_filters", filters, context.Resource, context.CurrentUser, context)
+	case !shared:
+		return storage.SaveLayer("saved_filters", PersonalSettingLayer, filters, context.Resource, context)
+	case role != "":
+		return storage.SaveRole("saved_filters", role, filters, context.Resource, context)
+	default:
+		return storage.SaveLayer("saved_filters", GlobalSettingLayer, filters, context.Resource, context)
+	}
+}
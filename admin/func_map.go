@@ -20,6 +20,7 @@ import (
 	"github.com/jinzhu/inflection"
 	"github.com/saitofun/qor/gorm"
 	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
 	"github.com/saitofun/qor/qor/utils"
 	"github.com/saitofun/qor/roles"
 	"github.com/saitofun/qor/session"
@@ -37,6 +38,7 @@ func (context *Context) FuncMap() template.FuncMap {
 		"is_equal":             context.isEqual,
 		"is_included":          context.isIncluded,
 		"primary_key_of":       context.primaryKeyOf,
+		"primary_value_of":     context.primaryValueOf,
 		"unique_key_of":        context.uniqueKeyOf,
 		"formatted_value_of":   context.FormattedValueOf,
 		"raw_value_of":         context.RawValueOf,
@@ -122,6 +124,8 @@ func (context *Context) FuncMap() template.FuncMap {
 			key := fmt.Sprintf("%v.attributes.%v", meta.baseResource.ToParam(), meta.Label)
 			return context.Admin.T(context.Context, key, meta.Label)
 		},
+		"can_save_personal_filters": context.canSavePersonalFilters,
+		"shared_filters_role":       context.sharedFiltersRole,
 		"meta_placeholder": func(meta *Meta, context *Context, placeholder string) template.HTML {
 			if getPlaceholder, ok := meta.Config.(interface {
 				GetPlaceholder(*Context) (template.HTML, bool)
@@ -168,12 +172,17 @@ func (context *Context) FuncMap() template.FuncMap {
 		"new_sections":              context.newSections,
 		"edit_sections":             context.editSections,
 		"convert_sections_to_metas": context.convertSectionToMetas,
+		"is_deleted":                context.isDeleted,
+		"audit_log_url":             context.auditLogURL,
+		"versions_url":              context.versionsURL,
 
 		"has_create_permission": context.hasCreatePermission,
 		"has_read_permission":   context.hasReadPermission,
 		"has_update_permission": context.hasUpdatePermission,
 		"has_delete_permission": context.hasDeletePermission,
 		"has_change_permission": context.hasChangePermission,
+		"has_export_permission": context.hasExportPermission,
+		"export_url":            context.exportURL,
 
 		"qor_theme_class":        context.themesClass,
 		"javascript_tag":         context.javaScriptTag,
@@ -218,7 +227,7 @@ func (context *Context) URLFor(value interface{}, resources ...*Resource) string
 	getPrefix := func(res *Resource) string {
 		var params string
 		for res.ParentResource != nil {
-			params = path.Join(res.ParentResource.ToParam(), res.ParentResource.GetPrimaryValue(context.Request), params)
+			params = path.Join(res.ParentResource.ToParam(), escapePrimaryValue(res.ParentResource.GetPrimaryValue(context.Request)), params)
 			res = res.ParentResource
 		}
 		return path.Join(res.GetAdmin().router.Prefix, params)
@@ -244,52 +253,17 @@ func (context *Context) URLFor(value interface{}, resources ...*Resource) string
 				return path.Join(getPrefix(res), res.ToParam())
 			}
 
-			var (
-				val           = reflect.ValueOf(value)
-				schema, _     = gorm.Parse(value)
-				primaryFields []string
-				primaryValues = map[string]string{}
-			)
-
-			for _, primaryField := range res.PrimaryFields {
-				if field, ok := schema.FieldsByName[primaryField.Name]; ok {
-					val, _ := field.ValueOf(val)
-					primaryFields = append(primaryFields, url.PathEscape(fmt.Sprint(val)))
-				}
-			}
-
-			for _, field := range schema.PrimaryFields {
-				useAsPrimaryField := false
-				for _, primaryField := range res.PrimaryFields {
-					if field.DBName == primaryField.DBName {
-						useAsPrimaryField = true
-						break
-					}
-				}
-
-				if !useAsPrimaryField {
-					key := fmt.Sprintf("primary_key[%v_%v]",
-						schema.Table, field.DBName)
-					v, _ := field.ValueOf(val)
-					primaryValues[key] = fmt.Sprint(v)
-				}
-			}
-
-			urlPath := path.Join(getPrefix(res), res.ToParam(), strings.Join(primaryFields, ","))
-
-			if len(primaryValues) > 0 {
-				var primaryValueParams []string
-				for key, value := range primaryValues {
-					primaryValueParams = append(primaryValueParams, fmt.Sprintf("%v=%v", key, url.QueryEscape(value)))
-				}
-				urlPath = urlPath + "?" + strings.Join(primaryValueParams, "&")
-			}
-			return urlPath
+			return path.Join(getPrefix(res), res.ToParam(), escapePrimaryValue(res.PrimaryValueOf(value)))
 		}
 	}
 	return ""
 }
 
+// escapePrimaryValue escape encoded primary value as path segment, separators of multiple primary values are kept readable
+func escapePrimaryValue(value string) string {
+	return strings.Replace(url.PathEscape(value), "%2C", resource.PrimaryValueSeparator, -1)
+}
+
 // RawValueOf return raw value of a meta for current resource
 func (context *Context) RawValueOf(value interface{}, meta *Meta) interface{} {
 	return context.valueOf(meta.GetValuer(), value, meta)
@@ -399,6 +373,23 @@ func (context *Context) primaryKeyOf(value interface{}) interface{} {
 	return fmt.Sprint(value)
 }
 
+// primaryValueOf return encoded primary value of record, which is used as selected records' primary values of batch actions
+func (context *Context) primaryValueOf(value interface{}, resources ...*Resource) string {
+	var res *Resource
+	if len(resources) > 0 {
+		res = resources[0]
+	}
+
+	if res == nil {
+		res = context.Admin.GetResource(reflect.Indirect(reflect.ValueOf(value)).Type().String())
+	}
+
+	if res != nil {
+		return res.PrimaryValueOf(value)
+	}
+	return fmt.Sprint(context.primaryKeyOf(value))
+}
+
 func (context *Context) uniqueKeyOf(value interface{}) interface{} {
 	if reflect.Indirect(reflect.ValueOf(value)).Kind() == reflect.Struct {
 		scope, _ := gorm.Parse(value)
@@ -556,8 +547,22 @@ func (context *Context) renderFilter(filter *Filter) template.HTML {
 }
 
 func (context *Context) savedFilters() (filters []SavedFilter) {
-	context.Admin.SettingsStorage.Get("saved_filters", &filters, context)
-	return
+	if _, ok := context.Admin.SettingsStorage.(LayeredSettingsStorageInterface); !ok {
+		context.Admin.SettingsStorage.Get("saved_filters", &filters, context)
+		return
+	}
+
+	if qor.CurrentUserID(context.CurrentUser) != "" {
+		filters, _ = context.loadSavedFilters(false, "")
+	}
+
+	for _, role := range context.Roles {
+		shared, _ := context.loadSavedFilters(true, role)
+		filters = append(filters, shared...)
+	}
+
+	shared, _ := context.loadSavedFilters(true, "")
+	return append(filters, shared...)
 }
 
 func (context *Context) renderMeta(meta *Meta, value interface{}, prefix []string, metaType string, writer *bytes.Buffer) {
@@ -901,6 +906,10 @@ func (context *Context) hasDeletePermission(permissioner HasPermissioner) bool {
 	return permissioner.HasPermission(roles.Delete, context.Context)
 }
 
+func (context *Context) isDeleted(record interface{}) bool {
+	return context.Resource != nil && context.Resource.IsDeleted(record)
+}
+
 func (context *Context) hasChangePermission(permissioner HasPermissioner) bool {
 	if context.Action == "new" {
 		return context.hasCreatePermission(permissioner)
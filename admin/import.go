@@ -0,0 +1,617 @@
+package admin
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/roles"
+)
+
+// ImportBatchSize rows count of each transaction when importing records
+var ImportBatchSize = 100
+
+// ImportPreviewSize rows count shown in the preview page
+var ImportPreviewSize = 20
+
+// ImportDir directory to keep uploaded files and error reports between import steps, system's temp directory will be used if it is blank
+var ImportDir = ""
+
+// ImportFileExpiration uploaded files and error reports older than it will be removed, e.g: files of abandoned imports or error reports that are never downloaded
+var ImportFileExpiration = 24 * time.Hour
+
+// ImportFormat format of imported files
+type ImportFormat struct {
+	ReadRows func(r io.Reader) ([][]string, error)
+}
+
+// ImportFormats registered import formats, key is the file extension
+var ImportFormats = map[string]*ImportFormat{
+	"csv":  {ReadRows: readCSVRows},
+	"xlsx": {ReadRows: readXLSXRows},
+}
+
+// Importer import rows into resource, rows are decoded with metas of new/edit attributes and saved with resource's CallSave in batched transactions
+//
+//	importer := &admin.Importer{Resource: products, Header: rows[0], Rows: rows[1:], Mapping: map[int]string{0: "Code", 1: "Name"}, Key: "Code"}
+//	result, err := importer.Import(context)
+type Importer struct {
+	Resource *Resource
+	Header   []string
+	Rows     [][]string
+	// Mapping column index => meta name, unmapped columns will be ignored
+	Mapping map[int]string
+	// Key primary field or unique field used to find existing records, which will be updated, records will always be created if it is blank
+	Key string
+}
+
+// ImportRow imported row
+type ImportRow struct {
+	// Line row number in the file, header is row 1
+	Line     int
+	Values   []string
+	Record   interface{}
+	Updating bool
+	Errors   qor.Errors
+}
+
+// ImportResult result of importing
+type ImportResult struct {
+	Total    int
+	Created  int
+	Updated  int
+	Rows     []*ImportRow
+	Rejected []*ImportRow
+}
+
+// errImportDryRun used to rollback batches in dry run mode
+var errImportDryRun = errors.New("import dry run")
+
+// Import import rows, rows that failed to be decoded or saved are rejected without affecting others,
+// in dry run mode, every batch will be rollbacked, so the result could be used as a preview
+func (importer *Importer) Import(context *Context) (*ImportResult, error) {
+	var (
+		res    = importer.Resource
+		metas  = importer.metas(context)
+		result = &ImportResult{}
+	)
+
+	if importer.Key != "" && !inStrings(importer.Key, res.importKeyFields()) {
+		return nil, fmt.Errorf("%v is not a primary field or unique field", importer.Key)
+	}
+
+	qorContext := context.Context.Clone()
+	for start := 0; start < len(importer.Rows); start += ImportBatchSize {
+		end := start + ImportBatchSize
+		if end > len(importer.Rows) {
+			end = len(importer.Rows)
+		}
+
+		err := qorContext.Transaction(func(tx *gorm.DB) error {
+			batchContext := qorContext.Clone()
+			batchContext.SetDB(tx)
+
+			for idx := start; idx < end; idx++ {
+				if strings.TrimSpace(strings.Join(importer.Rows[idx], "")) == "" {
+					// skip blank rows, e.g: trailing rows of spreadsheets
+					continue
+				}
+
+				result.Total++
+				row := &ImportRow{Line: idx + 2, Values: importer.Rows[idx]}
+				if row.Errors.AddError(importer.importRow(row, metas, batchContext)); row.Errors.HasError() {
+					result.Rejected = append(result.Rejected, row)
+				} else if row.Updating {
+					result.Updated++
+				} else {
+					result.Created++
+				}
+
+				if len(result.Rows) < ImportPreviewSize {
+					result.Rows = append(result.Rows, row)
+				}
+			}
+
+			if qorContext.DryRun {
+				return errImportDryRun
+			}
+			return nil
+		})
+
+		if err != nil && !errors.Is(err, errImportDryRun) {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// importRow decode and save row in a nested transaction, so the batch could be continued if it is failed
+func (importer *Importer) importRow(row *ImportRow, metas []*Meta, context *qor.Context) error {
+	return context.Transaction(func(tx *gorm.DB) error {
+		rowContext := context.Clone()
+		rowContext.SetDB(tx)
+
+		record, err := importer.findRecord(row, rowContext)
+		if err != nil {
+			return err
+		}
+		row.Record = record
+
+		metaValues, err := importer.metaValues(row, metas)
+		if err != nil {
+			return err
+		}
+
+		if err := resource.DecodeToResource(importer.Resource, record, metaValues, rowContext).Start(); err != nil {
+			return err
+		}
+		return importer.Resource.CallSave(record, rowContext)
+	})
+}
+
+// metaValues convert row to meta values with mapped columns as header, so nested metas could be imported with columns like Address.City or Items[0].SKU
+func (importer *Importer) metaValues(row *ImportRow, metas []*Meta) (*resource.MetaValues, error) {
+	var (
+		indexes []int
+		header  []string
+		values  []string
+		metaors []resource.Metaor
+	)
+
+	for idx := range importer.Mapping {
+		if idx < len(row.Values) {
+			indexes = append(indexes, idx)
+		}
+	}
+	sort.Ints(indexes)
+
+	for _, idx := range indexes {
+		header = append(header, importer.Mapping[idx])
+		values = append(values, row.Values[idx])
+	}
+
+	for _, meta := range metas {
+		metaors = append(metaors, meta)
+	}
+
+	results, err := resource.ConvertRowsToMetaValues([][]string{header, values}, metaors)
+	if err != nil || len(results) == 0 {
+		// mapped columns are all blank
+		return &resource.MetaValues{}, err
+	}
+	return results[0], nil
+}
+
+// findRecord find existing record with row's key value, a new record will be returned if not found
+func (importer *Importer) findRecord(row *ImportRow, context *qor.Context) (interface{}, error) {
+	var (
+		res       = importer.Resource
+		record    = res.NewStruct()
+		schema, _ = gorm.Parse(record)
+		keyValue  string
+	)
+
+	if importer.Key == "" {
+		return record, nil
+	}
+
+	for idx, name := range importer.Mapping {
+		if name == importer.Key && idx < len(row.Values) {
+			keyValue = strings.TrimSpace(row.Values[idx])
+		}
+	}
+
+	field := schema.LookUpField(importer.Key)
+	if keyValue == "" || field == nil {
+		return record, nil
+	}
+
+	db := res.ScopeRecordPolicies(roles.Update, res.ScopeTenant(context.GetDB(), context), context)
+	err := db.Where(fmt.Sprintf("%v.%v = ?", db.Statement.Quote(schema.Table), db.Statement.Quote(field.DBName)), keyValue).First(record).Error
+	if err == nil {
+		// existing records couldn't be updated without update permission, even if the importer could create records
+		if !res.HasPermission(roles.Update, context) {
+			return nil, roles.ErrPermissionDenied
+		}
+		row.Updating = true
+		context.ResourceID = res.PrimaryValueOf(record)
+		return record, nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// create the record with given key value
+		return record, field.Set(reflect.ValueOf(record), keyValue)
+	}
+	return nil, err
+}
+
+// metas metas that could be imported, which are metas of new attributes and edit attributes
+func (importer *Importer) metas(context *Context) (metas []*Meta) {
+	res := importer.Resource
+	for _, sections := range [][]*Section{
+		res.allowedSections(res.NewAttrs(), context, roles.Create),
+		res.allowedSections(res.EditAttrs(), context, roles.Update),
+	} {
+	Metas:
+		for _, meta := range res.ConvertSectionToMetas(sections) {
+			for _, m := range metas {
+				if m.Name == meta.Name {
+					continue Metas
+				}
+			}
+			metas = append(metas, meta)
+		}
+	}
+	return
+}
+
+// autoMapping map columns to metas or key fields that have same name or label
+func (importer *Importer) autoMapping(context *Context) map[int]string {
+	var (
+		res     = importer.Resource
+		mapping = map[int]string{}
+		names   = map[string]string{}
+	)
+
+	for _, field := range res.importKeyFields() {
+		names[strings.ToLower(field)] = field
+	}
+
+	for _, meta := range importer.metas(context) {
+		label := context.Admin.T(context.Context, fmt.Sprintf("%v.attributes.%v", meta.baseResource.ToParam(), meta.Label), meta.Label)
+		for _, name := range []string{meta.Name, meta.Label, string(label)} {
+			names[strings.ToLower(name)] = meta.Name
+		}
+	}
+
+	for idx, column := range importer.Header {
+		column = strings.TrimSpace(column)
+		if name, ok := names[strings.ToLower(column)]; ok {
+			mapping[idx] = name
+		} else if pos := strings.IndexAny(column, ".["); pos > 0 {
+			// nested columns, e.g: Address.City, Items[0].SKU
+			if name, ok := names[strings.ToLower(strings.TrimSpace(column[:pos]))]; ok && !inStrings(name, res.importKeyFields()) {
+				mapping[idx] = name + column[pos:]
+			}
+		}
+	}
+	return mapping
+}
+
+// importMappingRoot meta name of mapped column, e.g: Address of Address.City, Items of Items[0].SKU
+func importMappingRoot(name string) string {
+	if pos := strings.IndexAny(name, ".["); pos > 0 {
+		return strings.TrimSpace(name[:pos])
+	}
+	return name
+}
+
+// importKeyFields primary field and unique fields that could be used to find existing records
+func (res *Resource) importKeyFields() (fields []string) {
+	schema, err := gorm.Parse(res.Value)
+	if err != nil {
+		return
+	}
+
+	if len(schema.PrimaryFields) == 1 {
+		fields = append(fields, schema.PrimaryFields[0].Name)
+	}
+
+	for _, field := range schema.Fields {
+		if field.Unique && !inStrings(field.Name, fields) {
+			fields = append(fields, field.Name)
+		}
+	}
+
+	for _, index := range schema.ParseIndexes() {
+		if index.Class == "UNIQUE" && len(index.Fields) == 1 && !inStrings(index.Fields[0].Name, fields) {
+			fields = append(fields, index.Fields[0].Name)
+		}
+	}
+	return
+}
+
+func inStrings(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// importPage data of import page, step is one of upload, mapping, preview and result
+type importPage struct {
+	Step      string
+	Token     string
+	Importer  *Importer
+	Metas     []*Meta
+	KeyFields []string
+	Result    *ImportResult
+	context   *Context
+}
+
+// ValueOf formatted value of imported record
+func (page *importPage) ValueOf(row *ImportRow, meta *Meta) string {
+	if row.Record == nil {
+		return ""
+	}
+	return exportValue(page.context.FormattedValueOf(row.Record, meta))
+}
+
+// ErrorsOf error messages of imported row
+func (page *importPage) ErrorsOf(row *ImportRow) (messages []string) {
+	for _, err := range row.Errors.GetErrors() {
+		messages = append(messages, err.Error())
+	}
+	return
+}
+
+// IsKeyField check the name is a key field or not, key fields are listed before metas in mapping options
+func (page *importPage) IsKeyField(name string) bool {
+	return inStrings(name, page.KeyFields)
+}
+
+// IsNestedMapping check the column is mapped to a nested meta or not, e.g: Address.City
+func (page *importPage) IsNestedMapping(name string) bool {
+	return importMappingRoot(name) != name
+}
+
+// MappedMetas metas that columns are mapped to, used as columns of preview table
+func (page *importPage) MappedMetas() (metas []*Meta) {
+	for _, meta := range page.Metas {
+		for _, name := range page.Importer.Mapping {
+			if importMappingRoot(name) == meta.Name {
+				metas = append(metas, meta)
+				break
+			}
+		}
+	}
+	return
+}
+
+// Import import records from uploaded csv or xlsx files, the wizard is: upload file => map columns => preview => import,
+// uploaded file is kept with a token between steps, rejected rows could be downloaded as an error report after imported
+func (ac *Controller) Import(context *Context) {
+	page := &importPage{Step: "upload", context: context}
+	if context.Request.Method == "GET" {
+		context.Execute("import", page)
+		return
+	}
+
+	removeExpiredImportFiles(context.Resource)
+
+	var err error
+	if file, header, formErr := context.Request.FormFile("QorResource.ImportFile"); formErr == nil {
+		defer file.Close()
+		page.Token, err = saveImportFile(context.Resource, file, strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), "."))
+	} else {
+		page.Token = context.Request.Form.Get("QorResource.ImportToken")
+	}
+
+	if err == nil {
+		page.Importer, err = loadImporter(context.Resource, page.Token)
+	}
+
+	if err != nil {
+		context.AddError(err)
+		context.Writer.WriteHeader(HTTPUnprocessableEntity)
+		context.Execute("import", page)
+		return
+	}
+
+	page.Metas = page.Importer.metas(context)
+	page.KeyFields = context.Resource.importKeyFields()
+
+	if _, ok := context.Request.Form["QorResource.ImportKey"]; !ok {
+		page.Step = "mapping"
+		page.Importer.Mapping = page.Importer.autoMapping(context)
+		if len(page.KeyFields) > 0 {
+			for _, name := range page.Importer.Mapping {
+				if name == page.KeyFields[0] {
+					page.Importer.Key = name
+				}
+			}
+		}
+		context.Execute("import", page)
+		return
+	}
+
+	page.Importer.Key = context.Request.Form.Get("QorResource.ImportKey")
+	for idx := range page.Importer.Header {
+		if name := context.Request.Form.Get(fmt.Sprintf("QorResource.ImportMapping.%v", idx)); name != "" {
+			page.Importer.Mapping[idx] = name
+		}
+	}
+
+	page.Step = "preview"
+	importContext := *context
+	importContext.Context = context.Context.Clone()
+	if importContext.DryRun = context.DryRun || context.Request.Form.Get("QorResource.ImportCommit") == ""; !importContext.DryRun {
+		page.Step = "result"
+	}
+
+	if page.Result, err = page.Importer.Import(&importContext); err == nil && page.Step == "result" {
+		// uploaded file is removed after imported, only error report of rejected rows is kept for downloading
+		if err = writeImportErrorReport(context.Resource, page.Token, page.Importer.Header, page.Result.Rejected); err == nil {
+			removeImportFile(context.Resource, page.Token, "")
+		}
+	}
+
+	if err != nil {
+		context.AddError(err)
+		context.Writer.WriteHeader(HTTPUnprocessableEntity)
+	}
+	context.Execute("import", page)
+}
+
+// ImportErrors download error report of rejected rows, the report is the original rows with an errors column
+func (ac *Controller) ImportErrors(context *Context) {
+	removeExpiredImportFiles(context.Resource)
+
+	token := context.Request.URL.Query().Get("token")
+	file, err := openImportFile(context.Resource, token, ".errors.csv")
+	if err != nil {
+		http.NotFound(context.Writer, context.Request)
+		return
+	}
+
+	context.Writer.Header().Set("Content-Type", ExportFormats["csv"].ContentType)
+	context.Writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(context.Resource.ToParam())+"-errors.csv"))
+	_, err = io.Copy(context.Writer, file)
+	file.Close()
+
+	// error report is removed after downloaded, so rejected rows won't be kept in the server
+	if err == nil {
+		removeImportFile(context.Resource, token, ".errors.csv")
+	}
+}
+
+var importTokenRegexp = regexp.MustCompile(`^\d+\.(\w+)$`)
+
+func importFilePrefix(res *Resource) string {
+	return "qor-import-" + strings.Replace(res.ToParam(), "/", "_", -1) + "-"
+}
+
+// saveImportFile save uploaded file into ImportDir, return its token
+func saveImportFile(res *Resource, reader io.Reader, ext string) (string, error) {
+	if _, ok := ImportFormats[ext]; !ok {
+		return "", fmt.Errorf("unsupported import format: %v", ext)
+	}
+
+	file, err := ioutil.TempFile(ImportDir, importFilePrefix(res)+"*."+ext)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(filepath.Base(file.Name()), importFilePrefix(res)), nil
+}
+
+// importFilePath path of the token's file, files of other resources couldn't be accessed with it
+func importFilePath(res *Resource, token string) (string, error) {
+	if !importTokenRegexp.MatchString(token) {
+		return "", fmt.Errorf("invalid import token: %v", token)
+	}
+
+	return filepath.Join(importDir(), importFilePrefix(res)+token), nil
+}
+
+func importDir() string {
+	if ImportDir == "" {
+		return os.TempDir()
+	}
+	return ImportDir
+}
+
+// removeImportFile remove file of the token with suffix
+func removeImportFile(res *Resource, token string, suffix string) error {
+	filePath, err := importFilePath(res, token)
+	if err != nil {
+		return err
+	}
+	return os.Remove(filePath + suffix)
+}
+
+// removeExpiredImportFiles remove uploaded files and error reports of the resource that are older than ImportFileExpiration
+func removeExpiredImportFiles(res *Resource) {
+	filePaths, _ := filepath.Glob(filepath.Join(importDir(), importFilePrefix(res)+"*"))
+	for _, filePath := range filePaths {
+		if info, err := os.Stat(filePath); err == nil && time.Since(info.ModTime()) > ImportFileExpiration {
+			os.Remove(filePath)
+		}
+	}
+}
+
+// openImportFile open file of the token with suffix, e.g: `.errors.csv` for error report
+func openImportFile(res *Resource, token string, suffix string) (*os.File, error) {
+	filePath, err := importFilePath(res, token)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(filePath + suffix)
+}
+
+// loadImporter load rows of uploaded file, the first row is header
+func loadImporter(res *Resource, token string) (*Importer, error) {
+	file, err := openImportFile(res, token, "")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rows, err := ImportFormats[importTokenRegexp.FindStringSubmatch(token)[1]].ReadRows(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("no rows found in the file")
+	}
+	return &Importer{Resource: res, Header: rows[0], Rows: rows[1:], Mapping: map[int]string{}}, nil
+}
+
+// writeImportErrorReport write rejected rows with their errors into error report of the token
+func writeImportErrorReport(res *Resource, token string, header []string, rejected []*ImportRow) error {
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	filePath, err := importFilePath(res, token)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath + ".errors.csv")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := ExportFormats["csv"].NewWriter(file)
+	if err := writer.WriteRow(append([]string{"Line"}, append(header, "Errors")...)); err != nil {
+		return err
+	}
+
+	for _, row := range rejected {
+		var messages []string
+		for _, err := range row.Errors.GetErrors() {
+			messages = append(messages, err.Error())
+		}
+
+		values := make([]string, len(header))
+		copy(values, row.Values)
+		if err := writer.WriteRow(append([]string{strconv.Itoa(row.Line)}, append(values, strings.Join(messages, "; "))...)); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// readCSVRows read rows of csv file, UTF-8 BOM added by spreadsheets will be removed
+func readCSVRows(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, err
+}
@@ -0,0 +1,300 @@
+package admin_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/qor/utils"
+	"github.com/saitofun/qor/roles"
+)
+
+type Coupon struct {
+	gorm.Model
+	Code     string `gorm:"unique"`
+	Discount int
+}
+
+type Supplier struct {
+	gorm.Model
+	Name     string `gorm:"unique"`
+	Address  SupplierAddress
+	Products []SupplierProduct
+}
+
+type SupplierAddress struct {
+	gorm.Model
+	SupplierID uint
+	City       string
+}
+
+type SupplierProduct struct {
+	gorm.Model
+	SupplierID uint
+	SKU        string
+}
+
+func uploadImportFile(t *testing.T, importURL, filename string, content []byte) (token string, body string) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	part, _ := writer.CreateFormFile("QorResource.ImportFile", filename)
+	part.Write(content)
+	writer.Close()
+
+	resp, err := http.Post(importURL, writer.FormDataContentType(), &buffer)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("file should be uploaded, but got %v", err)
+	}
+
+	data, _ := ioutil.ReadAll(resp.Body)
+	matches := regexp.MustCompile(`name="QorResource.ImportToken" value="([^"]+)"`).FindStringSubmatch(string(data))
+	if len(matches) != 2 {
+		t.Fatalf("import token should be rendered, but got %v", string(data))
+	}
+	return matches[1], string(data)
+}
+
+func TestImportRecords(t *testing.T) {
+	importDB := openTestDB(t, "import")
+	importDB.AutoMigrate(&Coupon{})
+	importDB.Create(&Coupon{Code: "A", Discount: 5})
+
+	admin.ImportDir = t.TempDir()
+	defer func() { admin.ImportDir = "" }()
+
+	adm := admin.New(&qor.Config{DB: importDB})
+	coupons := adm.AddResource(&Coupon{})
+	coupons.AddValidator(&resource.Validator{
+		Name: "discount",
+		Handler: func(record interface{}, metaValues *resource.MetaValues, context *qor.Context) error {
+			if discount := metaValues.Get("Discount"); discount != nil && utils.ToInt(discount.Value) > 100 {
+				return errors.New("discount should be less than 100")
+			}
+			return nil
+		},
+	})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	importURL := server.URL + "/admin/coupons/!import"
+	token, body := uploadImportFile(t, importURL, "coupons.csv", []byte("Code,Discount,Note\nA,10,updated\nB,20,created\n\nC,200,rejected\n"))
+	if !strings.Contains(body, `<option value="Code" selected>`) || !strings.Contains(body, `<option value="Discount" selected>`) {
+		t.Errorf("columns should be mapped to metas with same name")
+	}
+
+	form := url.Values{
+		"QorResource.ImportToken":     {token},
+		"QorResource.ImportKey":       {"Code"},
+		"QorResource.ImportMapping.0": {"Code"},
+		"QorResource.ImportMapping.1": {"Discount"},
+	}
+
+	resp, _ := http.PostForm(importURL, form)
+	if data, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(data), "Created: 1, Updated: 1, Rejected: 1") || !strings.Contains(string(data), "discount should be less than 100") {
+		t.Errorf("import should be previewed with errors, but got %v", string(data))
+	}
+
+	var count int64
+	if importDB.Model(&Coupon{}).Where("code = ? AND discount = ?", "A", 5).Count(&count); count != 1 {
+		t.Errorf("records shouldn't be changed when previewing")
+	}
+
+	form.Set("QorResource.ImportCommit", "true")
+	resp, _ = http.PostForm(importURL, form)
+	data, _ := ioutil.ReadAll(resp.Body)
+	if !strings.Contains(string(data), "Created: 1, Updated: 1, Rejected: 1") {
+		t.Errorf("records should be imported, but got %v", string(data))
+	}
+
+	for code, discount := range map[string]int{"A": 10, "B": 20} {
+		var coupon Coupon
+		if importDB.First(&coupon, "code = ?", code); coupon.Discount != discount {
+			t.Errorf("coupon %v should be imported with discount %v, but got %v", code, discount, coupon.Discount)
+		}
+	}
+
+	if importDB.Model(&Coupon{}).Where("code = ?", "C").Count(&count); count != 0 {
+		t.Errorf("rejected rows shouldn't be imported")
+	}
+
+	resp, _ = http.Get(fmt.Sprintf("%v/errors?token=%v", importURL, token))
+	if report, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(report), "4,C,200,rejected,discount should be less than 100") {
+		t.Errorf("rejected rows should be included in error report, but got %v", string(report))
+	}
+
+	if resp, _ := http.Get(importURL + "/errors?token=../" + token); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("error report shouldn't be found with invalid token")
+	}
+}
+
+func TestImportExportedXLSX(t *testing.T) {
+	importDB := openTestDB(t, "import_xlsx")
+	importDB.AutoMigrate(&Coupon{})
+	importDB.Create(&Coupon{Code: "A", Discount: 5})
+	importDB.Create(&Coupon{Code: "B", Discount: 10})
+
+	admin.ImportDir = t.TempDir()
+	defer func() { admin.ImportDir = "" }()
+
+	adm := admin.New(&qor.Config{DB: importDB})
+	adm.AddResource(&Coupon{}).IndexAttrs("ID", "Code", "Discount")
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	resp, _ := http.Get(server.URL + "/admin/coupons/!export.xlsx")
+	content, _ := ioutil.ReadAll(resp.Body)
+
+	token, body := uploadImportFile(t, server.URL+"/admin/coupons/!import", "coupons.xlsx", content)
+	if !strings.Contains(body, `<option value="ID" selected>`) {
+		t.Errorf("primary field should be selected as key, but got %v", body)
+	}
+
+	resp, _ = http.PostForm(server.URL+"/admin/coupons/!import", url.Values{
+		"QorResource.ImportToken":     {token},
+		"QorResource.ImportKey":       {"ID"},
+		"QorResource.ImportMapping.0": {"ID"},
+		"QorResource.ImportMapping.1": {"Code"},
+		"QorResource.ImportMapping.2": {"Discount"},
+	})
+	if data, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(data), "Created: 0, Updated: 2, Rejected: 0") {
+		t.Errorf("exported records should be updated by primary key, but got %v", string(data))
+	}
+}
+
+func TestImportNestedColumns(t *testing.T) {
+	importDB := openTestDB(t, "import_nested")
+	importDB.AutoMigrate(&Supplier{}, &SupplierAddress{}, &SupplierProduct{})
+
+	admin.ImportDir = t.TempDir()
+	defer func() { admin.ImportDir = "" }()
+
+	adm := admin.New(&qor.Config{DB: importDB})
+	adm.AddResource(&Supplier{})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	importURL := server.URL + "/admin/suppliers/!import"
+	token, body := uploadImportFile(t, importURL, "suppliers.csv", []byte("Name,address.City,Products[0].SKU,Products[1].SKU\nACME,Paris,P1,P2\nGlobex,Berlin,G1,\n"))
+	for _, mapped := range []string{"Address.City", "Products[0].SKU", "Products[1].SKU"} {
+		if !strings.Contains(body, fmt.Sprintf(`<option value="%v" selected>`, mapped)) {
+			t.Errorf("nested column should be mapped to %v, but got %v", mapped, body)
+		}
+	}
+
+	resp, _ := http.PostForm(importURL, url.Values{
+		"QorResource.ImportToken":     {token},
+		"QorResource.ImportKey":       {"Name"},
+		"QorResource.ImportMapping.0": {"Name"},
+		"QorResource.ImportMapping.1": {"Address.City"},
+		"QorResource.ImportMapping.2": {"Products[0].SKU"},
+		"QorResource.ImportMapping.3": {"Products[1].SKU"},
+		"QorResource.ImportCommit":    {"true"},
+	})
+	if data, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(data), "Created: 2, Updated: 0, Rejected: 0") {
+		t.Errorf("rows with nested columns should be imported, but got %v", string(data))
+	}
+
+	for name, expected := range map[string][]string{"ACME": {"Paris", "P1", "P2"}, "Globex": {"Berlin", "G1"}} {
+		var supplier Supplier
+		importDB.Preload("Address").Preload("Products", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).First(&supplier, "name = ?", name)
+
+		got := []string{supplier.Address.City}
+		for _, product := range supplier.Products {
+			got = append(got, product.SKU)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Errorf("supplier %v should be imported with %v, but got %v", name, expected, got)
+		}
+	}
+}
+
+func TestImportWithoutUpdatePermission(t *testing.T) {
+	importDB := openTestDB(t, "import_permission")
+	importDB.AutoMigrate(&Coupon{})
+	importDB.Create(&Coupon{Code: "A", Discount: 5})
+
+	admin.ImportDir = t.TempDir()
+	defer func() { admin.ImportDir = "" }()
+
+	adm := admin.New(&qor.Config{DB: importDB})
+	adm.AddResource(&Coupon{}, &admin.Config{Permission: roles.Deny(roles.Update, roles.Anyone)})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	importURL := server.URL + "/admin/coupons/!import"
+	token, _ := uploadImportFile(t, importURL, "coupons.csv", []byte("Code,Discount\nA,10\nB,20\n"))
+	resp, _ := http.PostForm(importURL, url.Values{
+		"QorResource.ImportToken":     {token},
+		"QorResource.ImportKey":       {"Code"},
+		"QorResource.ImportMapping.0": {"Code"},
+		"QorResource.ImportMapping.1": {"Discount"},
+		"QorResource.ImportCommit":    {"true"},
+	})
+	if data, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(data), "Created: 1, Updated: 0, Rejected: 1") {
+		t.Errorf("existing records shouldn't be updated without update permission, but got %v", string(data))
+	}
+
+	var coupon Coupon
+	if importDB.First(&coupon, "code = ?", "A"); coupon.Discount != 5 {
+		t.Errorf("existing record shouldn't be changed, but got discount %v", coupon.Discount)
+	}
+}
+
+func TestImportFilesRemoved(t *testing.T) {
+	importDB := openTestDB(t, "import_files")
+	importDB.AutoMigrate(&Coupon{})
+
+	admin.ImportDir = t.TempDir()
+	defer func() { admin.ImportDir = "" }()
+
+	adm := admin.New(&qor.Config{DB: importDB})
+	adm.AddResource(&Coupon{})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	expired := filepath.Join(admin.ImportDir, "qor-import-coupons-1.csv")
+	ioutil.WriteFile(expired, []byte("Code\nX\n"), 0600)
+	os.Chtimes(expired, time.Now().Add(-2*admin.ImportFileExpiration), time.Now().Add(-2*admin.ImportFileExpiration))
+
+	importURL := server.URL + "/admin/coupons/!import"
+	token, _ := uploadImportFile(t, importURL, "coupons.csv", []byte("Code,Discount\nA,10\n,\nA,20\n"))
+	if _, err := os.Stat(expired); !os.IsNotExist(err) {
+		t.Errorf("expired import files should be removed")
+	}
+
+	http.PostForm(importURL, url.Values{
+		"QorResource.ImportToken":     {token},
+		"QorResource.ImportKey":       {""},
+		"QorResource.ImportMapping.0": {"Code"},
+		"QorResource.ImportMapping.1": {"Discount"},
+		"QorResource.ImportCommit":    {"true"},
+	})
+
+	files, _ := filepath.Glob(filepath.Join(admin.ImportDir, "*"))
+	if len(files) != 1 || !strings.HasSuffix(files[0], ".errors.csv") {
+		t.Errorf("uploaded file should be removed after imported, only error report should be kept, but got %v", files)
+	}
+
+	if resp, _ := http.Get(fmt.Sprintf("%v/errors?token=%v", importURL, token)); resp.StatusCode != http.StatusOK {
+		t.Errorf("error report should be downloaded, but got %v", resp.StatusCode)
+	}
+
+	if files, _ := filepath.Glob(filepath.Join(admin.ImportDir, "*")); len(files) != 0 {
+		t.Errorf("error report should be removed after downloaded, but got %v", files)
+	}
+}
@@ -0,0 +1,154 @@
+package admin
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (text xlsxText) String() string {
+	value := text.Text
+	for _, run := range text.Runs {
+		value += run.Text
+	}
+	return value
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXRows read rows of the first sheet of xlsx file, values are read as they are stored, e.g: dates are serial numbers
+func readXLSXRows(r io.Reader) ([][]string, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sharedStrings struct {
+			Items []xlsxText `xml:"si"`
+		}
+		workbook struct {
+			Sheets []struct {
+				ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+			} `xml:"sheets>sheet"`
+		}
+		relationships struct {
+			Items []struct {
+				ID     string `xml:"Id,attr"`
+				Target string `xml:"Target,attr"`
+			} `xml:"Relationship"`
+		}
+		sheet     xlsxSheet
+		sheetPath = "xl/worksheets/sheet1.xml"
+	)
+
+	if err := readXLSXFile(archive, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+		return nil, err
+	}
+
+	if readXLSXFile(archive, "xl/workbook.xml", &workbook) == nil && readXLSXFile(archive, "xl/_rels/workbook.xml.rels", &relationships) == nil && len(workbook.Sheets) > 0 {
+		for _, relationship := range relationships.Items {
+			if relationship.ID == workbook.Sheets[0].ID {
+				if strings.HasPrefix(relationship.Target, "/") {
+					sheetPath = strings.TrimPrefix(relationship.Target, "/")
+				} else {
+					sheetPath = path.Join("xl", relationship.Target)
+				}
+			}
+		}
+	}
+
+	if err := readXLSXFile(archive, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		index := len(rows)
+		if row.Index > 0 {
+			index = row.Index - 1
+		}
+
+		for len(rows) <= index {
+			rows = append(rows, []string{})
+		}
+
+		for column, cell := range row.Cells {
+			if cell.Ref != "" {
+				column = xlsxColumnIndex(cell.Ref)
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("invalid shared string of cell %v", cell.Ref)
+				}
+				value = sharedStrings.Items[idx].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			}
+
+			for len(rows[index]) <= column {
+				rows[index] = append(rows[index], "")
+			}
+			rows[index][column] = value
+		}
+	}
+	return rows, nil
+}
+
+// readXLSXFile decode xml file of xlsx archive, missing files are ignored
+func readXLSXFile(archive *zip.Reader, name string, value interface{}) error {
+	for _, file := range archive.File {
+		if file.Name == name {
+			reader, err := file.Open()
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+			return xml.NewDecoder(reader).Decode(value)
+		}
+	}
+	return nil
+}
+
+// xlsxColumnIndex column index of cell reference, e.g: `B3` => 1
+func xlsxColumnIndex(ref string) (index int) {
+	for _, c := range strings.ToUpper(ref) {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		index = index*26 + int(c-'A'+1)
+	}
+	return index - 1
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/roles"
+)
+
+// JSONSchema generate JSON schema of resource for permission mode, e.g: roles.Create for payloads of creating records
+func (res *Resource) JSONSchema(mode roles.PermissionMode, context *qor.Context) resource.JSONSchema {
+	return resource.JSONSchemaOf(res, mode, context)
+}
+
+// ConfigureJSONSchema configure meta's JSON schema with its type, e.g: values of select one, select many metas are primary keys of selected records
+func (meta *Meta) ConfigureJSONSchema(schema resource.JSONSchema, mode roles.PermissionMode, context *qor.Context) resource.JSONSchema {
+	switch meta.Type {
+	case "select_one", "select_many":
+		item := schema
+		if items, ok := schema["items"].(resource.JSONSchema); ok && meta.Type == "select_many" {
+			item = items
+		}
+
+		if meta.FieldStruct != nil {
+			if rel, ok := meta.FieldStruct.Schema.Relationships.Relations[meta.FieldStruct.Name]; ok && rel.FieldSchema.PrioritizedPrimaryField != nil {
+				item = resource.JSONSchemaOfType(rel.FieldSchema.PrioritizedPrimaryField.FieldType)
+			}
+		}
+
+		if values := meta.collectionValues(); len(values) > 0 {
+			item["enum"] = values
+		}
+
+		if meta.Type == "select_many" {
+			schema = resource.JSONSchema{"type": "array", "items": item, "uniqueItems": true}
+		} else {
+			schema = item
+		}
+	case "rich_editor":
+		schema["contentMediaType"] = "text/html"
+	case "password":
+		schema["writeOnly"] = true
+	}
+
+	if meta.FieldStruct != nil && meta.FieldStruct.Size > 0 && schema["type"] == "string" {
+		if _, ok := schema["maxLength"]; !ok {
+			schema["maxLength"] = meta.FieldStruct.Size
+		}
+	}
+
+	if meta.Label != "" {
+		schema["title"] = meta.Label
+	}
+	return schema
+}
+
+// collectionValues values of static collections of select one, select many metas
+func (meta *Meta) collectionValues() (values []interface{}) {
+	var collection interface{}
+	switch config := meta.Config.(type) {
+	case *SelectOneConfig:
+		collection = config.Collection
+	case *SelectManyConfig:
+		collection = config.Collection
+	}
+
+	switch c := collection.(type) {
+	case []string:
+		for _, value := range c {
+			values = append(values, value)
+		}
+	case [][]string:
+		for _, value := range c {
+			if len(value) > 0 {
+				values = append(values, value[0])
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,70 @@
+package admin_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+)
+
+func TestJSONSchema(t *testing.T) {
+	userRes := Admin.GetResource("User")
+	userRes.AddValidator(&resource.Validator{
+		Name:     "json_schema",
+		Required: []string{"Name"},
+		Handler: func(interface{}, *resource.MetaValues, *qor.Context) error {
+			return nil
+		},
+	})
+
+	resp, err := http.Get(fmt.Sprintf("%v/admin/users/!schema.json?mode=create", server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema struct {
+		Schema     string                            `json:"$schema"`
+		Type       string                            `json:"type"`
+		Required   []string                          `json:"required"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("JSON schema should be served, but got %v, %v", resp.StatusCode, err)
+	}
+
+	if resp.Header.Get("Content-Type") != "application/schema+json" || schema.Schema != resource.JSONSchemaDraft || schema.Type != "object" {
+		t.Errorf("JSON schema should be a draft 2020-12 object schema, but got %v, %v", schema.Schema, schema.Type)
+	}
+
+	if len(schema.Required) != 1 || schema.Required[0] != "Name" {
+		t.Errorf("metas required by validators should be required, but got %v", schema.Required)
+	}
+
+	for name, expected := range map[string]string{
+		"Name":         `{"maxLength":50,"title":"Name","type":"string"}`,
+		"Age":          `{"minimum":0,"title":"Age","type":"integer"}`,
+		"Active":       `{"title":"Active","type":"boolean"}`,
+		"RegisteredAt": `{"format":"date-time","title":"Registered At","type":["string","null"]}`,
+		"Company":      `{"minimum":0,"title":"Company","type":"integer"}`,
+		"Languages":    `{"items":{"minimum":0,"type":"integer"},"title":"Languages","type":"array","uniqueItems":true}`,
+	} {
+		if property, _ := json.Marshal(schema.Properties[name]); string(property) != expected {
+			t.Errorf("schema of %v should be %v, but got %v", name, expected, string(property))
+		}
+	}
+
+	addresses := schema.Properties["Addresses"]
+	if items, ok := addresses["items"].(map[string]interface{}); !ok || addresses["type"] != "array" || items["type"] != "object" {
+		t.Errorf("nested collections should be arrays of objects, but got %v", addresses)
+	} else if properties, ok := items["properties"].(map[string]interface{}); !ok || properties["Address1"] == nil {
+		t.Errorf("nested collections should include metas of nested resource, but got %v", items)
+	}
+
+	if creditCard := schema.Properties["CreditCard"]; creditCard["type"] != "object" || creditCard["properties"] == nil {
+		t.Errorf("nested resource should be objects, but got %v", creditCard)
+	}
+}
@@ -30,6 +30,7 @@ type Meta struct {
 	Valuer          func(interface{}, *qor.Context) interface{}
 	FormattedValuer func(interface{}, *qor.Context) interface{}
 	Permission      *roles.Permission
+	MaskPolicy      *resource.MaskPolicy
 	Config          MetaConfigInterface
 	Collection      interface{}
 	Resource        *Resource
@@ -48,6 +49,12 @@ func (meta *Meta) SetPermission(permission *roles.Permission) {
 	}
 }
 
+// SetMaskPolicy set meta's masking policy, its values will be masked in pages, JSON, XML and exports for contexts that should not see them in full
+func (meta *Meta) SetMaskPolicy(policy *resource.MaskPolicy) {
+	meta.MaskPolicy = policy
+	meta.Meta.MaskPolicy = policy
+}
+
 // HasPermission check has permission or not
 func (meta Meta) HasPermission(mode roles.PermissionMode, context *qor.Context) bool {
 	var roles = []interface{}{}
@@ -121,6 +128,7 @@ func (meta *Meta) configure() {
 			BaseResource:    meta.baseResource,
 			Resource:        meta.Resource,
 			Permission:      meta.Permission,
+			MaskPolicy:      meta.MaskPolicy,
 			Config:          meta.Config,
 		}
 	} else {
@@ -132,6 +140,7 @@ func (meta *Meta) configure() {
 		meta.Meta.BaseResource = meta.baseResource
 		meta.Meta.Resource = meta.Resource
 		meta.Meta.Permission = meta.Permission
+		meta.Meta.MaskPolicy = meta.MaskPolicy
 		meta.Meta.Config = meta.Config
 	}
 
@@ -237,7 +246,11 @@ func (meta *Meta) configure() {
 						if len(primaryKeys) > 0 {
 							// set current field value to blank and replace it with new value
 							field.Set(reflect.Zero(field.Type()))
-							context.GetDB().Where(primaryKeys).Find(field.Addr().Interface())
+							db := context.GetDB()
+							if meta.Resource != nil {
+								db = meta.Resource.ScopeTenant(db, context)
+							}
+							db.Where(primaryKeys).Find(field.Addr().Interface())
 						}
 
 						schema, _ := gorm.Parse(resource)
@@ -27,6 +27,16 @@ type Config struct {
 	Singleton  bool
 	Invisible  bool
 	PageCount  int
+	// TenantColumn field or column name of tenant, records will be scoped with context's tenant if it is set
+	TenantColumn string
+	// DB database of resource, e.g: an analytics database, admin's DB will be used if it is nil
+	DB *gorm.DB
+	// DBResolver resolve database of resource for operations, e.g: qor.ReadReplicas(replica) to list records from a read replica
+	DBResolver qor.DBResolver
+	// Cache cache of records found by primary value, e.g: resource.NewLRUCache(1000), records are cached per tenant, roles and scopes
+	Cache resource.Cache
+	// Versioning snapshot records with their nested records every time they are saved, versions could be compared and reverted from record's history page
+	Versioning bool
 }
 
 // Resource is the most important thing for qor admin, every model is defined as a resource, qor admin will genetate management interface based on its definition
@@ -99,7 +109,7 @@ func (res *Resource) RoutePrefix() string {
 	return params
 }
 
-// GetPrimaryValue get priamry value from request
+// GetPrimaryValue get priamry value from request, multiple primary values are encoded with resource.EncodePrimaryValues
 func (res Resource) GetPrimaryValue(request *http.Request) string {
 	if request != nil {
 		return request.URL.Query().Get(res.ParamIDName())
@@ -278,6 +288,11 @@ Fields:
 			}
 		}
 
+		// type of interface fields is unknown until they are set, e.g: media.FileHeader
+		if field.FieldType.Kind() == reflect.Interface {
+			continue
+		}
+
 		if gorm.IsNormalField(field) {
 			attrs = append(attrs, field.Name)
 		}
@@ -602,6 +617,10 @@ func (res *Resource) Meta(meta *Meta) *Meta {
 			oldMeta.Permission = meta.Permission
 		}
 
+		if meta.MaskPolicy != nil {
+			oldMeta.MaskPolicy = meta.MaskPolicy
+		}
+
 		if meta.Config != nil {
 			oldMeta.Config = meta.Config
 		}
@@ -42,6 +42,7 @@ func newRouter() *Router {
 	return &Router{routers: map[string][]*routeHandler{
 		"GET":    {},
 		"PUT":    {},
+		"PATCH":  {},
 		"POST":   {},
 		"DELETE": {},
 	}}
@@ -104,6 +105,12 @@ func (r *Router) Put(path string, handle requestHandler, config ...*RouteConfig)
 	r.sortRoutes(r.routers["PUT"])
 }
 
+// Patch register a PATCH request handle with the given path
+func (r *Router) Patch(path string, handle requestHandler, config ...*RouteConfig) {
+	r.routers["PATCH"] = append(r.routers["PATCH"], newRouteHandler(path, handle, config...))
+	r.sortRoutes(r.routers["PATCH"])
+}
+
 // Delete register a DELETE request handle with the given path
 func (r *Router) Delete(path string, handle requestHandler, config ...*RouteConfig) {
 	r.routers["DELETE"] = append(r.routers["DELETE"], newRouteHandler(path, handle, config...))
@@ -195,6 +202,9 @@ func (serveMux *serveMux) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		context      = admin.NewContext(w, req)
 	)
 
+	// Bind request's context, so db queries will be cancelled if the client went away
+	context.SetContext(req.Context())
+
 	// Parse Request Form
 	req.ParseMultipartForm(2 * 1024 * 1024)
 	defer func() {
@@ -229,14 +239,18 @@ func (serveMux *serveMux) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			return
 		}
 		context.CurrentUser = currentUser
-		context.SetDB(context.GetDB().Set("qor:current_user", context.CurrentUser))
 	}
 	context.Roles = roles.MatchedRoles(req, currentUser)
 
+	// Validate-only mode, changes will be rollbacked
+	if dryRun := req.URL.Query().Get("dry_run"); dryRun != "" && dryRun != "0" && dryRun != "false" {
+		context.DryRun = true
+	}
+
 	switch req.Method {
 	case "GET":
 		permissionMode = roles.Read
-	case "PUT":
+	case "PUT", "PATCH":
 		permissionMode = roles.Update
 	case "POST":
 		permissionMode = roles.Create
@@ -282,6 +296,11 @@ func (admin *Admin) RegisterResourceRouters(res *Resource, actions ...string) {
 			if !res.Config.Singleton {
 				// New
 				res.RegisterRoute("GET", "/new", adminController.New, &RouteConfig{PermissionMode: roles.Create})
+
+				// Import
+				res.RegisterRoute("GET", "/!import", adminController.Import, &RouteConfig{PermissionMode: roles.Create})
+				res.RegisterRoute("POST", "/!import", adminController.Import, &RouteConfig{PermissionMode: roles.Create})
+				res.RegisterRoute("GET", "/!import/errors", adminController.ImportErrors, &RouteConfig{PermissionMode: roles.Create})
 			}
 
 			// Create
@@ -293,6 +312,7 @@ func (admin *Admin) RegisterResourceRouters(res *Resource, actions ...string) {
 
 				// Update
 				res.RegisterRoute("PUT", "/", adminController.Update, &RouteConfig{PermissionMode: roles.Update})
+				res.RegisterRoute("PATCH", "/", adminController.Update, &RouteConfig{PermissionMode: roles.Update})
 			} else {
 				// Edit
 				res.RegisterRoute("GET", path.Join(primaryKeyParams, "edit"), adminController.Edit, &RouteConfig{PermissionMode: roles.Update})
@@ -300,8 +320,17 @@ func (admin *Admin) RegisterResourceRouters(res *Resource, actions ...string) {
 				// Update
 				res.RegisterRoute("POST", primaryKeyParams, adminController.Update, &RouteConfig{PermissionMode: roles.Update})
 				res.RegisterRoute("PUT", primaryKeyParams, adminController.Update, &RouteConfig{PermissionMode: roles.Update})
+				res.RegisterRoute("PATCH", primaryKeyParams, adminController.Update, &RouteConfig{PermissionMode: roles.Update})
+
+				if res.Config.Versioning {
+					// Revert Version
+					res.RegisterRoute("POST", path.Join(primaryKeyParams, "!versions", "revert"), adminController.RevertVersion, &RouteConfig{PermissionMode: roles.Update})
+				}
 			}
 		case "read":
+			// JSON Schema
+			res.RegisterRoute("GET", "/!schema", adminController.Schema, &RouteConfig{PermissionMode: roles.Read})
+
 			if res.Config.Singleton {
 				// Index
 				res.RegisterRoute("GET", "/", adminController.Show, &RouteConfig{PermissionMode: roles.Read})
@@ -309,8 +338,17 @@ func (admin *Admin) RegisterResourceRouters(res *Resource, actions ...string) {
 				// Index
 				res.RegisterRoute("GET", "/", adminController.Index, &RouteConfig{PermissionMode: roles.Read})
 
+				// Export
+				res.RegisterRoute("GET", "/!export", adminController.Export, &RouteConfig{PermissionMode: roles.Export})
+
 				// Show
 				res.RegisterRoute("GET", primaryKeyParams, adminController.Show, &RouteConfig{PermissionMode: roles.Read})
+
+				if res.Config.Versioning {
+					// Versions
+					res.RegisterRoute("GET", path.Join(primaryKeyParams, "!versions"), adminController.Versions, &RouteConfig{PermissionMode: roles.Read})
+					res.RegisterRoute("GET", path.Join(primaryKeyParams, "!versions", "compare"), adminController.CompareVersions, &RouteConfig{PermissionMode: roles.Read})
+				}
 			}
 		case "delete":
 			if !res.Config.Singleton {
@@ -358,6 +396,8 @@ func (res *Resource) RegisterRoute(method string, relativePath string, handler r
 		router.Post(path.Join(prefix, relativePath), handler, config)
 	case "PUT":
 		router.Put(path.Join(prefix, relativePath), handler, config)
+	case "PATCH":
+		router.Patch(path.Join(prefix, relativePath), handler, config)
 	case "DELETE":
 		router.Delete(path.Join(prefix, relativePath), handler, config)
 	}
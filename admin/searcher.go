@@ -13,6 +13,7 @@ import (
 	"github.com/saitofun/qor/qor"
 	"github.com/saitofun/qor/qor/resource"
 	"github.com/saitofun/qor/qor/utils"
+	"github.com/saitofun/qor/roles"
 )
 
 // filterRegexp used to parse url query to get filters
@@ -158,7 +159,7 @@ func (s *Searcher) filterData(context *qor.Context, withDefaultScope bool) *qor.
 	if orderBy := context.Request.Form.Get("order_by"); orderBy != "" {
 		if regexp.MustCompile("^[a-zA-Z_]+$").MatchString(orderBy) {
 			schema, _ := gorm.Parse(s.Context.Resource.Value)
-			if f, ok := schema.FieldsByName[strings.TrimSuffix(orderBy, "_desc")]; ok {
+			if f := schema.LookUpField(strings.TrimSuffix(orderBy, "_desc")); f != nil {
 				if strings.HasSuffix(orderBy, "_desc") {
 					db = db.Order(f.DBName + " DESC")
 				} else {
@@ -190,6 +191,11 @@ func (s *Searcher) parseContext(withDefaultScope bool) *qor.Context {
 		context  = searcher.Context.Context.Clone()
 	)
 
+	if withDefaultScope {
+		// records are listed and counted from db for reads, e.g: a read replica
+		context.SetDB(context.GetReadDB())
+	}
+
 	if context != nil && context.Request != nil {
 		// parse scopes
 		scopes := context.Request.Form["scopes"]
@@ -210,40 +216,58 @@ func (s *Searcher) parseContext(withDefaultScope bool) *qor.Context {
 		}
 
 		if savingName := context.Request.Form.Get("filter_saving_name"); savingName != "" {
-			var filters []SavedFilter
 			requestURL := context.Request.URL
 			requestURLQuery := context.Request.URL.Query()
 			requestURLQuery.Del("filter_saving_name")
+			requestURLQuery.Del("filter_saving_shared")
 			requestURL.RawQuery = requestURLQuery.Encode()
+
+			var (
+				shared = context.Request.Form.Get("filter_saving_shared") == "true"
+				role   string
+			)
+			if shared {
+				if role = searcher.Context.sharedFiltersRole(); role == "" {
+					context.AddError(roles.ErrPermissionDenied)
+				}
+			}
+
 			newFilters := []SavedFilter{{Name: savingName, URL: requestURL.String()}}
-			if context.AddError(searcher.Admin.SettingsStorage.Get("saved_filters", &filters, searcher.Context)); !context.HasError() {
+			filters, err := searcher.Context.loadSavedFilters(shared, role)
+			if context.AddError(err); !context.HasError() {
 				for _, filter := range filters {
 					if filter.Name != savingName {
 						newFilters = append(newFilters, filter)
 					}
 				}
 
-				context.AddError(searcher.Admin.SettingsStorage.Save("saved_filters", newFilters, searcher.Resource, context.CurrentUser, searcher.Context))
+				context.AddError(searcher.Context.saveSavedFilters(newFilters, shared, role))
 			}
 		}
 
 		if savingName := context.Request.Form.Get("delete_saved_filter"); savingName != "" {
-			var filters, newFilters []SavedFilter
-			if context.AddError(searcher.Admin.SettingsStorage.Get("saved_filters", &filters, searcher.Context)); !context.HasError() {
+			var (
+				newFilters []SavedFilter
+				shared     = context.Request.Form.Get("delete_saved_filter_shared") == "true"
+				role       = context.Request.Form.Get("delete_saved_filter_role")
+			)
+			filters, err := searcher.Context.loadSavedFilters(shared, role)
+			if context.AddError(err); !context.HasError() {
 				for _, filter := range filters {
 					if filter.Name != savingName {
 						newFilters = append(newFilters, filter)
 					}
 				}
 
-				context.AddError(searcher.Admin.SettingsStorage.Save("saved_filters", newFilters, searcher.Resource, context.CurrentUser, searcher.Context))
+				context.AddError(searcher.Context.saveSavedFilters(newFilters, shared, role))
 			}
 		}
 	}
 
 	searcher.filterData(context, withDefaultScope)
 
-	db := context.GetDB()
+	// new session, so conditions for counting won't be added to the filtered db, e.g: the one of scopes like `db.Unscoped().Where(...)`
+	db := context.GetDB().Session(&gorm.Session{})
 
 	// pagination
 	context.SetDB(db.Model(s.Resource.Value).Set("qor:getting_total_count", true))
@@ -2,10 +2,14 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/saitofun/qor/gorm"
 	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
 )
 
 // SettingsStorageInterface settings storage interface
@@ -21,36 +25,129 @@ func newSettings(db *gorm.DB) SettingsStorageInterface {
 	return settings{}
 }
 
-// QorAdminSetting admin settings
+// SettingLayer layer of admin settings, settings of role layer override global ones, and personal settings override both of them
+type SettingLayer string
+
+const (
+	// GlobalSettingLayer settings shared with all users, they could only be saved with update permission of admin's GlobalSettingsPermission
+	GlobalSettingLayer SettingLayer = "global"
+	// RoleSettingLayer settings shared with users of context's roles, they could only be saved for roles that have update permission of admin's RoleSettingsPermission
+	RoleSettingLayer SettingLayer = "role"
+	// PersonalSettingLayer settings of current user, current user need to implement qor.IdentifiableCurrentUser
+	PersonalSettingLayer SettingLayer = "personal"
+)
+
+// ErrUnknownSettingOwner settings couldn't be saved to personal or role layer as current user has no id or context has no roles
+var ErrUnknownSettingOwner = errors.New("admin: unknown owner of settings layer")
+
+// LayeredSettingsStorageInterface settings storage that could get and save settings of a single layer or a single role
+type LayeredSettingsStorageInterface interface {
+	SettingsStorageInterface
+	GetLayer(key string, layer SettingLayer, value interface{}, context *Context) error
+	SaveLayer(key string, layer SettingLayer, value interface{}, res *Resource, context *Context) error
+	GetRole(key string, role string, value interface{}, context *Context) error
+	SaveRole(key string, role string, value interface{}, res *Resource, context *Context) error
+}
+
+// QorAdminSetting admin settings, global settings have blank user id and role
 type QorAdminSetting struct {
 	gorm.Model
 	Key      string
 	Resource string
 	UserID   string
+	Role     string
 	Value    string `gorm:"size:65532"`
 }
 
+// Layer get layer of the setting
+func (setting QorAdminSetting) Layer() SettingLayer {
+	if setting.UserID != "" {
+		return PersonalSettingLayer
+	} else if setting.Role != "" {
+		return RoleSettingLayer
+	}
+	return GlobalSettingLayer
+}
+
 type settings struct{}
 
-// Get load admin settings
-func (settings) Get(key string, value interface{}, context *Context) error {
+// Get load admin settings, global, role and personal settings are applied in order, settings of context's resource are applied after general ones of each layer
+func (s settings) Get(key string, value interface{}, context *Context) error {
+	return s.get(key, []SettingLayer{GlobalSettingLayer, RoleSettingLayer, PersonalSettingLayer}, context.Roles, value, context)
+}
+
+// GetLayer load admin settings of the layer, settings of all context's roles are loaded for role layer
+func (s settings) GetLayer(key string, layer SettingLayer, value interface{}, context *Context) error {
+	return s.get(key, []SettingLayer{layer}, context.Roles, value, context)
+}
+
+// GetRole load admin settings of the role, nothing will be loaded if context doesn't have the role
+func (s settings) GetRole(key string, role string, value interface{}, context *Context) error {
+	if indexOfString(context.Roles, role) == -1 {
+		return nil
+	}
+	return s.get(key, []SettingLayer{RoleSettingLayer}, []string{role}, value, context)
+}
+
+func (settings) get(key string, layers []SettingLayer, ownerRoles []string, value interface{}, context *Context) error {
 	var (
-		settings  = []QorAdminSetting{}
-		tx        = context.GetDB().Session(&gorm.Session{})
-		resParams = ""
-		userID    = ""
+		settings   = []QorAdminSetting{}
+		tx         = context.Admin.dbOf(context.Context).Session(&gorm.Session{NewDB: true})
+		resParams  = ""
+		userID     = qor.CurrentUserID(context.CurrentUser)
+		conditions []string
+		values     = []interface{}{key}
 	)
-	sqlCondition := fmt.Sprintf("%v = ? AND (resource = ? OR resource = ?) AND (user_id = ? OR user_id = ?)", tx.Statement.Quote("key"))
 
 	if context.Resource != nil {
 		resParams = context.Resource.ToParam()
 	}
+	values = append(values, resParams, "")
+
+	for _, layer := range layers {
+		switch layer {
+		case GlobalSettingLayer:
+			conditions = append(conditions, "(user_id = ? AND role = ?)")
+			values = append(values, "", "")
+		case RoleSettingLayer:
+			if len(ownerRoles) > 0 {
+				conditions = append(conditions, "(user_id = ? AND role IN (?))")
+				values = append(values, "", ownerRoles)
+			}
+		case PersonalSettingLayer:
+			if userID != "" {
+				conditions = append(conditions, "(user_id = ?)")
+				values = append(values, userID)
+			}
+		}
+	}
 
-	if context.CurrentUser != nil {
-		userID = ""
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	sqlCondition := fmt.Sprintf("%v = ? AND (resource = ? OR resource = ?) AND (%v)", tx.Statement.Quote("key"), strings.Join(conditions, " OR "))
+	if err := tx.Where(sqlCondition, values...).Order("id").Find(&settings).Error; err != nil {
+		return err
 	}
 
-	tx.Where(sqlCondition, key, resParams, "", userID, "").Order("user_id DESC, resource DESC, id DESC").Find(&settings)
+	precedence := func(setting QorAdminSetting) int {
+		p := 0
+		switch setting.Layer() {
+		case RoleSettingLayer:
+			p = 2 * (1 + indexOfString(ownerRoles, setting.Role))
+		case PersonalSettingLayer:
+			p = 2 * (1 + len(ownerRoles) + 1)
+		}
+
+		if setting.Resource != "" {
+			p++
+		}
+		return p
+	}
+	sort.SliceStable(settings, func(i, j int) bool {
+		return precedence(settings[i]) < precedence(settings[j])
+	})
 
 	for _, setting := range settings {
 		if err := json.Unmarshal([]byte(setting.Value), value); err != nil {
@@ -61,13 +158,45 @@ func (settings) Get(key string, value interface{}, context *Context) error {
 	return nil
 }
 
-// Save save admin settings
-func (settings) Save(key string, value interface{}, res *Resource, user qor.CurrentUser, context *Context) error {
+// Save save admin settings, settings are saved as personal ones if user implements qor.IdentifiableCurrentUser, otherwise as global ones
+func (s settings) Save(key string, value interface{}, res *Resource, user qor.CurrentUser, context *Context) error {
+	if userID := qor.CurrentUserID(user); userID != "" {
+		return s.save(key, value, res, []QorAdminSetting{{UserID: userID}}, context)
+	}
+	return s.save(key, value, res, []QorAdminSetting{{}}, context)
+}
+
+// SaveLayer save admin settings to the layer, settings of role layer are saved for the first role of context in alphabetical order that could save settings, use SaveRole to save them for other roles
+func (s settings) SaveLayer(key string, layer SettingLayer, value interface{}, res *Resource, context *Context) error {
+	var owner QorAdminSetting
+	switch layer {
+	case RoleSettingLayer:
+		if len(context.Roles) == 0 {
+			return ErrUnknownSettingOwner
+		} else if owner.Role = context.Admin.roleSettingsOwner(context); owner.Role == "" {
+			return roles.ErrPermissionDenied
+		}
+	case PersonalSettingLayer:
+		if owner.UserID = qor.CurrentUserID(context.CurrentUser); owner.UserID == "" {
+			return ErrUnknownSettingOwner
+		}
+	}
+	return s.save(key, value, res, []QorAdminSetting{owner}, context)
+}
+
+// SaveRole save admin settings for the role, which need update permission of admin's RoleSettingsPermission
+func (s settings) SaveRole(key string, role string, value interface{}, res *Resource, context *Context) error {
+	if role == "" {
+		return ErrUnknownSettingOwner
+	}
+	return s.save(key, value, res, []QorAdminSetting{{Role: role}}, context)
+}
+
+func (settings) save(key string, value interface{}, res *Resource, owners []QorAdminSetting, context *Context) error {
 	var (
-		tx          = context.GetDB().Session(&gorm.Session{})
+		tx          = context.Admin.dbOf(context.Context).Session(&gorm.Session{NewDB: true})
 		result, err = json.Marshal(value)
 		resParams   = ""
-		userID      = ""
 	)
 
 	if err != nil {
@@ -78,15 +207,69 @@ func (settings) Save(key string, value interface{}, res *Resource, user qor.Curr
 		resParams = res.ToParam()
 	}
 
-	if user != nil {
-		userID = ""
+	for _, owner := range owners {
+		switch owner.Layer() {
+		case GlobalSettingLayer:
+			if !context.Admin.hasGlobalSettingsPermission(context) {
+				return roles.ErrPermissionDenied
+			}
+		case RoleSettingLayer:
+			if !context.Admin.hasRoleSettingsPermission(owner.Role, context) {
+				return roles.ErrPermissionDenied
+			}
+		}
 	}
 
-	err = tx.Where(QorAdminSetting{
-		Key:      key,
-		UserID:   userID,
-		Resource: resParams,
-	}).Assign(QorAdminSetting{Value: string(result)}).FirstOrCreate(&QorAdminSetting{}).Error
+	for _, owner := range owners {
+		var setting QorAdminSetting
+		if err := tx.Where(map[string]interface{}{"key": key, "resource": resParams, "user_id": owner.UserID, "role": owner.Role}).
+			Assign(QorAdminSetting{Value: string(result)}).
+			FirstOrCreate(&setting).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-	return err
+// hasGlobalSettingsPermission check context has permission to save settings of global layer or not
+func (admin *Admin) hasGlobalSettingsPermission(context *Context) bool {
+	if admin.GlobalSettingsPermission == nil {
+		return false
+	}
+
+	var currentRoles []interface{}
+	for _, role := range context.Roles {
+		currentRoles = append(currentRoles, role)
+	}
+	return admin.GlobalSettingsPermission.HasPermission(roles.Update, currentRoles...)
+}
+
+// hasRoleSettingsPermission check context has permission to save settings of the role or not, settings could only be saved for context's own roles
+func (admin *Admin) hasRoleSettingsPermission(role string, context *Context) bool {
+	if admin.RoleSettingsPermission == nil || indexOfString(context.Roles, role) == -1 {
+		return false
+	}
+	return admin.RoleSettingsPermission.HasPermission(roles.Update, role)
+}
+
+// roleSettingsOwner the first role of context in alphabetical order that has permission to save role settings, roles are sorted so settings are always saved for the same role
+func (admin *Admin) roleSettingsOwner(context *Context) string {
+	currentRoles := append([]string{}, context.Roles...)
+	sort.Strings(currentRoles)
+	for _, role := range currentRoles {
+		if admin.hasRoleSettingsPermission(role, context) {
+			return role
+		}
+	}
+	return ""
+}
+
+func indexOfString(strs []string, str string) int {
+	for idx, s := range strs {
+		if s == str {
+			return idx
+		}
+	}
+	return -1
 }
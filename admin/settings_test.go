@@ -0,0 +1,241 @@
+package admin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+type settingUser struct {
+	ID   string
+	Name string
+}
+
+func (user settingUser) DisplayName() string { return user.Name }
+
+func (user settingUser) GetID() interface{} { return user.ID }
+
+// guestUser current user without id
+type guestUser struct{}
+
+func (guestUser) DisplayName() string { return "guest" }
+
+type settingAuth struct{}
+
+func (settingAuth) GetCurrentUser(context *admin.Context) qor.CurrentUser {
+	if id := context.Request.Header.Get("X-User"); id == "guest" {
+		return guestUser{}
+	} else if id != "" {
+		return settingUser{ID: id, Name: id}
+	}
+	return nil
+}
+
+func (settingAuth) LoginURL(*admin.Context) string { return "/login" }
+
+func (settingAuth) LogoutURL(*admin.Context) string { return "/logout" }
+
+func TestSettingsLayers(t *testing.T) {
+	settingDB := openTestDB(t, "settings")
+	settingDB.AutoMigrate(&Coupon{})
+
+	adm := admin.New(&admin.AdminConfig{
+		DB:                       settingDB,
+		GlobalSettingsPermission: roles.Allow(roles.Update, "admin"),
+		RoleSettingsPermission:   roles.Allow(roles.Update, "manager"),
+	})
+	coupons := adm.AddResource(&Coupon{})
+	storage := adm.SettingsStorage.(admin.LayeredSettingsStorageInterface)
+
+	newContext := func(user qor.CurrentUser, roles ...string) *admin.Context {
+		return &admin.Context{Context: &qor.Context{DB: settingDB, CurrentUser: user, Roles: roles}, Admin: adm, Resource: coupons}
+	}
+
+	alice, bob := settingUser{ID: "1", Name: "alice"}, settingUser{ID: "2", Name: "bob"}
+	for _, s := range []struct {
+		context *admin.Context
+		layer   admin.SettingLayer
+		value   map[string]interface{}
+	}{
+		{newContext(nil, "admin"), admin.GlobalSettingLayer, map[string]interface{}{"page_size": "10", "theme": "light", "lang": "en"}},
+		{newContext(alice, "manager"), admin.RoleSettingLayer, map[string]interface{}{"theme": "dark", "lang": "fr"}},
+		{newContext(alice, "manager"), admin.PersonalSettingLayer, map[string]interface{}{"page_size": "50"}},
+	} {
+		if err := storage.SaveLayer("preferences", s.layer, s.value, coupons, s.context); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := storage.SaveLayer("preferences", admin.PersonalSettingLayer, map[string]interface{}{}, coupons, newContext(nil)); err != admin.ErrUnknownSettingOwner {
+		t.Errorf("personal settings couldn't be saved without current user's id, but got %v", err)
+	}
+
+	for _, context := range []*admin.Context{newContext(alice, "manager"), newContext(nil)} {
+		if err := storage.SaveLayer("preferences", admin.GlobalSettingLayer, map[string]interface{}{"theme": "red"}, coupons, context); err != roles.ErrPermissionDenied {
+			t.Errorf("global settings couldn't be saved without permission, but got %v", err)
+		}
+	}
+
+	for _, context := range []*admin.Context{newContext(bob, "staff"), newContext(bob, "staff", "manager")} {
+		if err := storage.SaveRole("preferences", "staff", map[string]interface{}{"theme": "red"}, coupons, context); err != roles.ErrPermissionDenied {
+			t.Errorf("role settings couldn't be saved for role without permission, but got %v", err)
+		}
+	}
+
+	if err := storage.SaveRole("preferences", "manager", map[string]interface{}{"theme": "red"}, coupons, newContext(bob, "staff")); err != roles.ErrPermissionDenied {
+		t.Errorf("role settings couldn't be saved for other roles, but got %v", err)
+	}
+
+	// role settings should be saved for manager only, as staff has no permission to save them
+	if err := storage.SaveLayer("preferences", admin.RoleSettingLayer, map[string]interface{}{"theme": "dark", "lang": "es"}, coupons, newContext(bob, "staff", "manager")); err != nil {
+		t.Fatal(err)
+	}
+
+	managerSettings := map[string]interface{}{}
+	storage.GetRole("preferences", "manager", &managerSettings, newContext(bob, "staff", "manager"))
+	if managerSettings["theme"] != "dark" || managerSettings["lang"] != "es" {
+		t.Errorf("role settings should be saved for the role that has permission, but got %v", managerSettings)
+	}
+
+	otherSettings := map[string]interface{}{}
+	storage.GetRole("preferences", "manager", &otherSettings, newContext(bob, "staff"))
+	if len(otherSettings) != 0 {
+		t.Errorf("settings of other roles shouldn't be loaded, but got %v", otherSettings)
+	}
+
+	if err := adm.SettingsStorage.Save("preferences", map[string]interface{}{"theme": "red"}, nil, nil, newContext(nil)); err != roles.ErrPermissionDenied {
+		t.Errorf("global settings couldn't be saved with legacy interface without permission, but got %v", err)
+	}
+
+	// save general settings with legacy interface, it should be saved as alice's personal settings
+	if err := adm.SettingsStorage.Save("preferences", map[string]interface{}{"lang": "de", "page_size": "20"}, nil, alice, newContext(alice)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		context  *admin.Context
+		expected map[string]interface{}
+	}{
+		{newContext(alice, "manager"), map[string]interface{}{"page_size": "50", "theme": "dark", "lang": "de"}},
+		{newContext(bob, "manager"), map[string]interface{}{"page_size": "10", "theme": "dark", "lang": "es"}},
+		{newContext(bob), map[string]interface{}{"page_size": "10", "theme": "light", "lang": "en"}},
+	} {
+		preferences := map[string]interface{}{}
+		if err := adm.SettingsStorage.Get("preferences", &preferences, c.context); err != nil {
+			t.Fatal(err)
+		}
+
+		for key, value := range c.expected {
+			if preferences[key] != value {
+				t.Errorf("%v of %v should be %v, but got %v", key, c.context.CurrentUser, value, preferences)
+			}
+		}
+	}
+
+	global := map[string]interface{}{}
+	storage.GetLayer("preferences", admin.GlobalSettingLayer, &global, newContext(alice, "manager"))
+	if global["page_size"] != "10" || global["lang"] != "en" {
+		t.Errorf("only settings of global layer should be loaded, but got %v", global)
+	}
+}
+
+func TestPersonalAndSharedSavedFilters(t *testing.T) {
+	settingDB := openTestDB(t, "saved_filters")
+	settingDB.AutoMigrate(&Coupon{})
+
+	roles.Register("staff", func(req *http.Request, user interface{}) bool {
+		u, ok := user.(settingUser)
+		return ok && u.ID != "carol"
+	})
+	roles.Register("lead", func(req *http.Request, user interface{}) bool {
+		u, ok := user.(settingUser)
+		return ok && u.ID == "alice"
+	})
+	defer roles.Remove("staff")
+	defer roles.Remove("lead")
+
+	adm := admin.New(&admin.AdminConfig{DB: settingDB, Auth: settingAuth{}, RoleSettingsPermission: roles.Allow(roles.Update, "staff")})
+	adm.AddResource(&Coupon{}, &admin.Config{Name: "Coupon"}).Filter(&admin.Filter{Name: "Code"})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	request := func(user string, query url.Values) string {
+		req, _ := http.NewRequest("GET", server.URL+"/admin/coupons?"+query.Encode(), nil)
+		req.Header.Set("X-User", user)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		var body strings.Builder
+		buf := make([]byte, 4096)
+		for {
+			n, err := resp.Body.Read(buf)
+			body.Write(buf[:n])
+			if err != nil {
+				break
+			}
+		}
+		return body.String()
+	}
+
+	// saved filters of global layer, which are saved before filters could be shared with roles
+	settingDB.Create(&admin.QorAdminSetting{Key: "saved_filters", Resource: "coupons", Value: `[{"Name":"Legacy","URL":"/admin/coupons"}]`})
+
+	request("bob", url.Values{"filter_saving_name": {"BobTeam"}, "filter_saving_shared": {"true"}, "filters[Code].Value": {"C"}})
+	request("alice", url.Values{"filter_saving_name": {"Mine"}, "filters[Code].Value": {"A"}})
+	request("alice", url.Values{"filter_saving_name": {"Team"}, "filter_saving_shared": {"true"}, "filters[Code].Value": {"B"}})
+
+	body := request("alice", nil)
+	for _, filter := range []string{
+		`data-filter-name="Mine" data-filter-shared="false" data-filter-role=""`,
+		`data-filter-name="Team" data-filter-shared="true" data-filter-role="staff"`,
+		`data-filter-name="BobTeam" data-filter-shared="true" data-filter-role="staff"`,
+		`data-filter-name="Legacy" data-filter-shared="true" data-filter-role=""`,
+	} {
+		if !strings.Contains(body, filter) {
+			t.Errorf("personal, shared and global filters should be listed for alice, but %v not found in %v", filter, body)
+		}
+	}
+
+	if !strings.Contains(body, `data-filter-personal="true" data-filter-role="staff"`) {
+		t.Errorf("alice should be able to save personal filters and share filters with staff, but got %v", body)
+	}
+
+	body = request("bob", nil)
+	if strings.Contains(body, `data-filter-name="Mine"`) || !strings.Contains(body, `data-filter-name="Team"`) || !strings.Contains(body, `data-filter-name="BobTeam"`) {
+		t.Errorf("only shared filters should be listed for other users of same roles, but got %v", body)
+	}
+
+	// carol has no role, so she couldn't share filters, or delete filters shared with staff
+	request("carol", url.Values{"filter_saving_name": {"CarolTeam"}, "filter_saving_shared": {"true"}})
+	request("carol", url.Values{"delete_saved_filter": {"Team"}, "delete_saved_filter_shared": {"true"}, "delete_saved_filter_role": {"staff"}})
+	if body := request("carol", nil); strings.Contains(body, `data-filter-name="Team"`) || strings.Contains(body, `data-filter-name="CarolTeam"`) ||
+		!strings.Contains(body, `data-filter-name="Legacy"`) || !strings.Contains(body, `data-filter-personal="true" data-filter-role=""`) {
+		t.Errorf("shared filters shouldn't be listed or saved for users of other roles, but got %v", body)
+	}
+
+	// guest has no id or role, so guest couldn't save filters
+	request("guest", url.Values{"filter_saving_name": {"Guest"}})
+	if body := request("guest", nil); strings.Contains(body, "data-filter-personal") || strings.Contains(body, `data-filter-name="Guest"`) || !strings.Contains(body, `data-filter-name="Legacy"`) {
+		t.Errorf("saving filters should be unavailable for users without id, but got %v", body)
+	}
+
+	var count int64
+	if settingDB.Model(&admin.QorAdminSetting{}).Where("user_id = ? AND role <> ?", "", "staff").Count(&count); count != 1 {
+		t.Errorf("shared filters should only be saved for the role that has permission, but got %v settings of other roles", count-1)
+	}
+
+	request("bob", url.Values{"delete_saved_filter": {"Mine"}})
+	request("bob", url.Values{"delete_saved_filter": {"Team"}, "delete_saved_filter_shared": {"true"}, "delete_saved_filter_role": {"staff"}})
+	if body := request("alice", nil); !strings.Contains(body, `data-filter-name="Mine"`) || strings.Contains(body, `data-filter-name="Team"`) || !strings.Contains(body, `data-filter-name="BobTeam"`) {
+		t.Errorf("saved filters should be deleted from their own layer, but got %v", body)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"reflect"
 
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
 	"github.com/saitofun/qor/roles"
 )
 
@@ -25,7 +27,24 @@ func (JSONTransformer) Encode(writer io.Writer, encoder Encoder) error {
 		res     = encoder.Resource
 	)
 
-	js, err := json.MarshalIndent(convertObjectToJSONMap(res, context, encoder.Result, encoder.Action), "", "\t")
+	result := convertObjectToJSONMap(res, context, encoder.Result, encoder.Action)
+
+	// include changes of the record if it has been decoded, e.g: after updated
+	if values, ok := result.(map[string]interface{}); ok && context != nil {
+		if changeSet := resource.GetChangeSet(context.Context, encoder.Result); len(changeSet) > 0 {
+			if res != nil {
+				changeSet = resource.MaskChangeSet(res, changeSet, context.Context)
+			}
+			values["_changes"] = changeSet
+		}
+
+		// soft deleted record, e.g: shown from trash
+		if res != nil && res.IsDeleted(encoder.Result) {
+			values["_deleted_at"], _ = res.GetSoftDeleteField().ValueOf(reflect.ValueOf(encoder.Result))
+		}
+	}
+
+	js, err := json.MarshalIndent(result, "", "\t")
 	if err != nil {
 		result := make(map[string]string)
 		result["error"] = err.Error()
@@ -41,6 +60,13 @@ func (JSONTransformer) Encode(writer io.Writer, encoder Encoder) error {
 }
 
 func convertObjectToJSONMap(res *Resource, context *Context, value interface{}, kind string) interface{} {
+	switch errs := value.(type) {
+	case qor.Errors:
+		return map[string]interface{}{"errors": errs.GetFieldErrors()}
+	case []qor.Error:
+		return errs
+	}
+
 	reflectValue := reflect.ValueOf(value)
 	for reflectValue.Kind() == reflect.Ptr {
 		reflectValue = reflectValue.Elem()
@@ -83,6 +109,15 @@ func convertObjectToJSONMap(res *Resource, context *Context, value interface{},
 				}
 			}
 		}
+
+		// lock value need to be posted back when updating the record
+		if res != nil {
+			if field := res.GetLockField(); field != nil {
+				if _, ok := values[field.Name]; !ok {
+					values[field.Name], _ = field.ValueOf(reflectValue)
+				}
+			}
+		}
 		return values
 	case reflect.Map:
 		for _, key := range reflectValue.MapKeys() {
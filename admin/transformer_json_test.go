@@ -10,6 +10,8 @@ import (
 	"github.com/saitofun/qor/admin"
 	. "github.com/saitofun/qor/admin/tests/dummy"
 	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/validations"
 	"github.com/theplant/testingutils"
 )
 
@@ -101,3 +103,21 @@ func TestJSONTransformerEncodeMap(t *testing.T) {
 		t.Errorf("Failed to decode errors map to JSON, except: %v, but got %v", except, buffer.String())
 	}
 }
+
+func TestJSONTransformerEncodeErrors(t *testing.T) {
+	var (
+		buffer          bytes.Buffer
+		errs            qor.Errors
+		jsonTransformer = &admin.JSONTransformer{}
+	)
+
+	errs.AddError(validations.NewErrorWithCode(&User{}, "Name", "required", "Name can't be blank"), errors.New("error"))
+	jsonTransformer.Encode(&buffer, admin.Encoder{Result: errs})
+
+	except := `{"errors":[{"path":"Name","code":"required","message":"Name can't be blank"},{"path":"","code":"invalid","message":"error"}]}`
+	var result bytes.Buffer
+	json.Compact(&result, buffer.Bytes())
+	if except != result.String() {
+		t.Errorf("Failed to encode errors to JSON, except: %v, but got %v", except, result.String())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jinzhu/inflection"
+	"github.com/saitofun/qor/qor"
 	"github.com/saitofun/qor/qor/utils"
 	"github.com/saitofun/qor/roles"
 )
@@ -40,6 +41,11 @@ func (XMLTransformer) Encode(writer io.Writer, encoder Encoder) error {
 	return err
 }
 
+// xmlErrors used to encode structured errors to xml
+type xmlErrors struct {
+	Errors []qor.Error `xml:"error"`
+}
+
 // XMLStruct used to decode resource to xml
 type XMLStruct struct {
 	Action   string
@@ -70,6 +76,10 @@ var XMLMarshalDefaultHandler = func(xmlStruct XMLStruct, e *xml.Encoder, start x
 	res := xmlStruct.Resource
 	context := xmlStruct.Context
 
+	if errs, ok := xmlStruct.Result.(qor.Errors); ok {
+		reflectValue = reflect.ValueOf(map[string]interface{}{"errors": errs.GetFieldErrors()})
+	}
+
 	switch reflectValue.Kind() {
 	case reflect.Map:
 		// Write Start Element
@@ -93,7 +103,9 @@ var XMLMarshalDefaultHandler = func(xmlStruct XMLStruct, e *xml.Encoder, start x
 			)
 
 			mapValue = reflect.Indirect(reflect.ValueOf(mapValue.Interface()))
-			if mapValue.Kind() == reflect.Map {
+			if errs, ok := mapValue.Interface().([]qor.Error); ok {
+				err = e.EncodeElement(xmlErrors{Errors: errs}, startElem)
+			} else if mapValue.Kind() == reflect.Map {
 				err = e.EncodeElement(xmlStruct.Initialize(mapValue.Interface(), xmlStruct.Resource), startElem)
 			} else {
 				err = e.EncodeElement(fmt.Sprint(reflectValue.MapIndex(mapKey).Interface()), startElem)
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+// TrashScopeName name of the scope that lists soft deleted records
+const TrashScopeName = "Trash"
+
+// configureTrash register trash scope, restore and delete permanently actions for soft deletable resources,
+// soft deleted records are excluded from index page by default, and could be found with the trash scope
+func (res *Resource) configureTrash() {
+	if res.GetSoftDeleteField() == nil {
+		return
+	}
+
+	res.Scope(&Scope{
+		Name:  TrashScopeName,
+		Label: "Trash",
+		Visible: func(context *Context) bool {
+			return res.HasPermission(roles.Restore, context.Context) || (res.HasPermission(roles.Delete, context.Context) && res.HasPermission(roles.Purge, context.Context))
+		},
+		Handler: res.ScopeTrash,
+	})
+
+	res.Action(&Action{
+		Name: "Delete",
+		Visible: func(record interface{}, context *Context) bool {
+			return !res.IsDeleted(record)
+		},
+	})
+
+	res.Action(&Action{
+		Name:    "Restore",
+		Visible: res.trashActionVisible(roles.Restore),
+		Handler: func(argument *ActionArgument) error {
+			return res.handleTrashAction(argument, res.CallRestore)
+		},
+		Modes: []string{"show", "menu_item", "batch"},
+	})
+
+	res.Action(&Action{
+		Name:    "Delete Permanently",
+		Label:   "Delete permanently",
+		Visible: res.trashActionVisible(roles.Delete, roles.Purge),
+		Handler: func(argument *ActionArgument) error {
+			return res.handleTrashAction(argument, res.CallPurge)
+		},
+		Modes: []string{"show", "menu_item", "batch"},
+	})
+}
+
+// trashActionVisible trash actions are visible for soft deleted records, or in trash scope for batch actions, if all modes are permitted
+func (res *Resource) trashActionVisible(modes ...roles.PermissionMode) func(interface{}, *Context) bool {
+	return func(record interface{}, context *Context) bool {
+		for _, mode := range modes {
+			if !res.HasPermission(mode, context.Context) {
+				return false
+			}
+		}
+
+		if record == nil {
+			if context.Request != nil {
+				for _, scope := range context.Request.URL.Query()["scopes"] {
+					if scope == TrashScopeName {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		return res.IsDeleted(record)
+	}
+}
+
+// handleTrashAction handle selected records with restore or purge method in a transaction
+func (res *Resource) handleTrashAction(argument *ActionArgument, handle func(interface{}, *qor.Context) error) error {
+	context := argument.Context
+	return context.Transaction(func(*gorm.DB) error {
+		for _, primaryValue := range argument.PrimaryValues {
+			clone := context.Context.Clone()
+			clone.ResourceID = primaryValue
+			if err := handle(res.NewStruct(), clone); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
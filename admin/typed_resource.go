@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+)
+
+// TypedResource typed wrapper of admin resource for records of T, records are found with scopes, filters of admin's searcher
+//
+//	products := admin.AddTypedResource[Product](Admin)
+//	products.AddValidatorFunc("name", func(product *Product, metaValues *resource.MetaValues, context *qor.Context) error { ... })
+//	records, err := products.FindMany(context)
+type TypedResource[T any] struct {
+	*Resource
+}
+
+// AddTypedResource make model T manageable from admin interface, and return it as typed resource
+func AddTypedResource[T any](admin *Admin, config ...*Config) *TypedResource[T] {
+	return TypedResourceOf[T](admin.AddResource(new(T), config...))
+}
+
+// TypedResourceOf wrap admin resource as typed resource of T, resource's value should be *T
+func TypedResourceOf[T any](res *Resource) *TypedResource[T] {
+	resource.Of[T](res.Resource)
+	return &TypedResource[T]{Resource: res}
+}
+
+// Typed return typed qor resource
+func (res *TypedResource[T]) Typed() *resource.Typed[T] {
+	return resource.Of[T](res.Resource.Resource)
+}
+
+// FindOne find record with context's ResourceID
+func (res *TypedResource[T]) FindOne(context *Context) (*T, error) {
+	result, err := res.newContext(context).Searcher.FindOne()
+	if err != nil {
+		return nil, err
+	}
+	value, ok := result.(*T)
+	if !ok {
+		return nil, fmt.Errorf("%T is not %T", result, value)
+	}
+	return value, nil
+}
+
+// FindMany find records with context's scopes, filters, keyword and pagination
+func (res *TypedResource[T]) FindMany(context *Context) ([]*T, error) {
+	results, err := res.newContext(context).Searcher.FindMany()
+	if err != nil {
+		return nil, err
+	}
+	values, ok := results.(*[]*T)
+	if !ok {
+		return nil, fmt.Errorf("%T is not %T", results, values)
+	}
+	return *values, nil
+}
+
+// FindSelectedRecords find selected records of action argument
+func (res *TypedResource[T]) FindSelectedRecords(argument *ActionArgument) (records []*T) {
+	for _, record := range argument.FindSelectedRecords() {
+		if value, ok := record.(*T); ok {
+			records = append(records, value)
+		}
+	}
+	return
+}
+
+// Save save record
+func (res *TypedResource[T]) Save(record *T, context *Context) error {
+	return res.CallSave(record, context.Context)
+}
+
+// AddValidatorFunc add typed validator, validator with same name will be replaced
+func (res *TypedResource[T]) AddValidatorFunc(name string, handler func(record *T, metaValues *resource.MetaValues, context *qor.Context) error) {
+	res.Typed().AddValidatorFunc(name, handler)
+}
+
+// AddProcessorFunc add typed processor, processor with same name will be replaced
+func (res *TypedResource[T]) AddProcessorFunc(name string, handler func(record *T, metaValues *resource.MetaValues, context *qor.Context) error) {
+	res.Typed().AddProcessorFunc(name, handler)
+}
+
+// newContext new context of the resource, context's ResourceID is kept
+func (res *TypedResource[T]) newContext(context *Context) *Context {
+	clone := context.NewResourceContext(res.Resource)
+	clone.ResourceID = context.ResourceID
+	return clone
+}
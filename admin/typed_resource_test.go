@@ -0,0 +1,47 @@
+package admin_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+)
+
+func TestTypedResource(t *testing.T) {
+	type TypedCategory struct {
+		gorm.Model
+		Name string
+	}
+
+	db.Migrator().DropTable(&TypedCategory{})
+	db.AutoMigrate(&TypedCategory{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	categories := admin.AddTypedResource[TypedCategory](adm)
+	categories.Scope(&admin.Scope{Name: "Books", Handler: func(db *gorm.DB, context *qor.Context) *gorm.DB {
+		return db.Where("name LIKE ?", "book%")
+	}})
+	adm.NewServeMux("/admin")
+
+	req := httptest.NewRequest("GET", "/admin/typed_categories?scopes=Books", nil)
+	req.ParseForm()
+
+	context := adm.NewContext(nil, req)
+	for _, name := range []string{"books", "movies"} {
+		if err := categories.Save(&TypedCategory{Name: name}, context); err != nil {
+			t.Fatalf("category should be saved, but got %v", err)
+		}
+	}
+
+	results, err := categories.FindMany(context)
+	if err != nil || len(results) != 1 || results[0].Name != "books" {
+		t.Errorf("categories should be found with scopes of request, but got %+v, %v", results, err)
+	}
+
+	context.ResourceID = categories.PrimaryValueOf(results[0])
+	if result, err := categories.FindOne(context); err != nil || result.ID != results[0].ID {
+		t.Errorf("category should be found, but got %+v, %v", result, err)
+	}
+}
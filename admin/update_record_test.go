@@ -2,11 +2,14 @@ package admin_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -14,10 +17,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/saitofun/qor/admin"
 	. "github.com/saitofun/qor/admin/tests/dummy"
 	"github.com/saitofun/qor/gorm"
 	"github.com/saitofun/qor/qor"
 	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/roles"
 )
 
 func TestUpdateRecord(t *testing.T) {
@@ -344,3 +349,480 @@ func TestUpdateAttachment(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateRecordWithConflict(t *testing.T) {
+	type LockedPage struct {
+		gorm.Model
+		Title   string
+		Version int
+	}
+
+	db.Migrator().DropTable(&LockedPage{})
+	db.AutoMigrate(&LockedPage{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	adm.AddResource(&LockedPage{}).SetLockField("Version")
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	page := LockedPage{Title: "draft", Version: 3}
+	db.Save(&page)
+
+	update := func(title string, version int) *http.Response {
+		body := fmt.Sprintf(`{"Title": "%v", "Version": %v}`, title, version)
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("%v/admin/locked_pages/%v.json", server.URL, page.ID), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := update("editor1", 3); resp.StatusCode != http.StatusOK {
+		t.Errorf("page should be updated, but got status %v", resp.StatusCode)
+	}
+
+	if resp := update("editor2", 3); resp.StatusCode != http.StatusConflict {
+		t.Errorf("stale page should get conflict status, but got %v", resp.StatusCode)
+	} else if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), `"code": "conflict"`) {
+		t.Errorf("conflict error should be returned, but got %v", string(body))
+	}
+
+	var result LockedPage
+	if db.First(&result, page.ID); result.Title != "editor1" || result.Version != 4 {
+		t.Errorf("page should keep first editor's change, but got %v, version %v", result.Title, result.Version)
+	}
+}
+
+func TestUpdateRecordWithTenant(t *testing.T) {
+	type TenantPage struct {
+		gorm.Model
+		Title    string
+		TenantID uint
+	}
+
+	db.Migrator().DropTable(&TenantPage{})
+	db.AutoMigrate(&TenantPage{})
+
+	adm := admin.New(&qor.Config{DB: db, TenantResolver: qor.TenantFromHeader("X-Tenant-ID")})
+	adm.AddResource(&TenantPage{}, &admin.Config{TenantColumn: "TenantID"})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	page1, page2 := TenantPage{Title: "page1", TenantID: 1}, TenantPage{Title: "page2", TenantID: 2}
+	db.Save(&page1)
+	db.Save(&page2)
+
+	request := func(method, path, body string) *http.Response {
+		req, _ := http.NewRequest(method, server.URL+"/admin/tenant_pages"+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Tenant-ID", "1")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := request("GET", ".json", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("pages should be listed, but got status %v", resp.StatusCode)
+	} else if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "page1") || strings.Contains(string(body), "page2") {
+		t.Errorf("should only list pages of current tenant, but got %v", string(body))
+	}
+
+	if resp := request("PUT", fmt.Sprintf("/%v.json", page2.ID), `{"Title": "stolen"}`); resp.StatusCode == http.StatusOK {
+		t.Errorf("page of other tenants should not be updated")
+	}
+
+	var result TenantPage
+	if db.First(&result, page2.ID); result.Title != "page2" {
+		t.Errorf("page of other tenants should not be changed, but got %v", result.Title)
+	}
+
+	if resp := request("POST", ".json", `{"Title": "page3", "TenantID": 2}`); resp.StatusCode != http.StatusCreated {
+		t.Errorf("page should be created, but got status %v", resp.StatusCode)
+	}
+
+	var created TenantPage
+	if db.Where("title = ?", "page3").First(&created); created.TenantID != 1 {
+		t.Errorf("page should be created with current tenant, but got %v", created.TenantID)
+	}
+}
+
+func TestUpdateRecordWithRecordPolicy(t *testing.T) {
+	type RegionalOrder struct {
+		gorm.Model
+		Code   string
+		Region string
+	}
+
+	db.Migrator().DropTable(&RegionalOrder{})
+	db.AutoMigrate(&RegionalOrder{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	order := adm.AddResource(&RegionalOrder{})
+	order.AddRecordPolicy(roles.CRUD, &resource.RecordPolicy{
+		Name: "region",
+		Check: func(record interface{}, context *qor.Context) bool {
+			return record.(*RegionalOrder).Region == context.Request.Header.Get("X-Region")
+		},
+		Scope: func(db *gorm.DB, context *qor.Context) *gorm.DB {
+			return db.Where("region = ?", context.Request.Header.Get("X-Region"))
+		},
+	})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	order1, order2 := RegionalOrder{Code: "order1", Region: "east"}, RegionalOrder{Code: "order2", Region: "west"}
+	db.Save(&order1)
+	db.Save(&order2)
+
+	request := func(method, path, body string) *http.Response {
+		req, _ := http.NewRequest(method, server.URL+"/admin/regional_orders"+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Region", "east")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := request("GET", ".json", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("orders should be listed, but got status %v", resp.StatusCode)
+	} else if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "order1") || strings.Contains(string(body), "order2") {
+		t.Errorf("should only list permitted orders, but got %v", string(body))
+	}
+
+	if resp := request("GET", fmt.Sprintf("/%v.json", order2.ID), ""); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("should get forbidden status when show order not permitted, but got %v", resp.StatusCode)
+	}
+
+	if resp := request("PUT", fmt.Sprintf("/%v.json", order1.ID), `{"Region": "west"}`); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("should get forbidden status when move order to region not permitted, but got %v", resp.StatusCode)
+	}
+
+	if resp := request("DELETE", fmt.Sprintf("/%v.json", order2.ID), ""); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("should get forbidden status when delete order not permitted, but got %v", resp.StatusCode)
+	}
+
+	var count int64
+	if db.Model(&RegionalOrder{}).Where("region = ?", "west").Count(&count); count != 1 {
+		t.Errorf("orders not permitted should not be changed, but got %v", count)
+	}
+}
+
+func TestUpdateRecordWithMaskedMeta(t *testing.T) {
+	type MaskedCustomer struct {
+		gorm.Model
+		Name  string
+		Email string
+	}
+
+	db.Migrator().DropTable(&MaskedCustomer{})
+	db.AutoMigrate(&MaskedCustomer{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	adm.AddResource(&MaskedCustomer{}).Meta(&admin.Meta{Name: "Email", MaskPolicy: &resource.MaskPolicy{Mask: resource.MaskEmail}})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	customer := MaskedCustomer{Name: "jinzhu", Email: "jinzhu@example.com"}
+	db.Save(&customer)
+
+	for _, format := range []string{"json", "xml"} {
+		resp, err := http.Get(fmt.Sprintf("%v/admin/masked_customers/%v.%v", server.URL, customer.ID, format))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), "j***@example.com") || strings.Contains(string(body), "jinzhu@example.com") {
+			t.Errorf("email should be masked in %v, but got %v", format, string(body))
+		}
+	}
+
+	body := `{"Name": "jinzhu 2", "Email": "j***@example.com"}`
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("%v/admin/masked_customers/%v.json", server.URL, customer.ID), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("customer should be updated, but got %v", err)
+	}
+
+	var result MaskedCustomer
+	if db.First(&result, customer.ID); result.Name != "jinzhu 2" || result.Email != "jinzhu@example.com" {
+		t.Errorf("masked email posted back should not overwrite the real value, but got %v, %v", result.Name, result.Email)
+	}
+}
+
+func TestUpdateRecordWithCompositePrimaryKey(t *testing.T) {
+	type Translation struct {
+		Key    string `gorm:"primaryKey"`
+		Locale string `gorm:"primaryKey"`
+		Value  string
+	}
+
+	db.Migrator().DropTable(&Translation{})
+	db.AutoMigrate(&Translation{})
+
+	adm := admin.New(&qor.Config{DB: db})
+	res := adm.AddResource(&Translation{})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	translation := Translation{Key: "greeting,hello/world 100%", Locale: "en-US", Value: "Hello"}
+	db.Save(&translation)
+	db.Save(&Translation{Key: translation.Key, Locale: "zh-CN", Value: "Nihao"})
+
+	translationURL := adm.NewContext(nil, nil).URLFor(&translation, res)
+	if translationURL != "/admin/translations/greeting~2Chello~2Fworld%20100%25,en-US" {
+		t.Errorf("primary values should be escaped in url, but got %v", translationURL)
+	}
+
+	resp, err := http.Get(server.URL + translationURL + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body, _ := ioutil.ReadAll(resp.Body); !strings.Contains(string(body), `"Hello"`) {
+		t.Errorf("translation should be found with composite primary key, but got %v", string(body))
+	}
+
+	req, _ := http.NewRequest("PUT", server.URL+translationURL+".json", strings.NewReader(`{"Value": "Hello World"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("translation should be updated, but got %v", err)
+	}
+
+	var translations []Translation
+	db.Order("locale").Find(&translations, "key = ?", translation.Key)
+	if len(translations) != 2 || translations[0].Value != "Hello World" || translations[1].Value != "Nihao" {
+		t.Errorf("only translation with the composite primary key should be updated, but got %+v", translations)
+	}
+}
+
+func TestMaskedMetaInVersionsAuditLogsAndPatches(t *testing.T) {
+	type MaskedContact struct {
+		gorm.Model
+		Name  string
+		Email string
+	}
+
+	maskDB := openTestDB(t, "masked_contacts")
+	maskDB.AutoMigrate(&MaskedContact{})
+
+	adm := admin.New(&admin.AdminConfig{DB: maskDB, AuditLog: true})
+	adm.AddResource(&MaskedContact{}, &admin.Config{Versioning: true}).Meta(&admin.Meta{Name: "Email", MaskPolicy: &resource.MaskPolicy{Mask: resource.MaskEmail}})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	request := func(method, path, contentType, body string) (int, string) {
+		req, _ := http.NewRequest(method, server.URL+"/admin/masked_contacts"+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	if status, body := request("POST", ".json", "application/json", `{"Name": "jinzhu", "Email": "jinzhu@example.com"}`); status != http.StatusCreated {
+		t.Fatalf("contact should be created, but got status %v, %v", status, body)
+	}
+
+	var contact MaskedContact
+	maskDB.First(&contact)
+	if status, body := request("PUT", fmt.Sprintf("/%v.json", contact.ID), "application/json", `{"Name": "jinzhu", "Email": "jinzhu2@example.com"}`); status != http.StatusOK {
+		t.Fatalf("contact should be updated, but got status %v, %v", status, body)
+	}
+
+	isClear := func(value string) bool {
+		return strings.Contains(value, "jinzhu@example.com") || strings.Contains(value, "jinzhu2@example.com")
+	}
+
+	var versions []admin.QorVersion
+	if maskDB.Find(&versions); len(versions) != 2 {
+		t.Fatalf("versions should be saved, but got %v", len(versions))
+	}
+
+	for _, version := range versions {
+		if isClear(version.Data) {
+			t.Errorf("masked email should not be saved in clear text in version, but got %v", version.Data)
+		}
+	}
+
+	var logs []admin.QorAuditLog
+	if maskDB.Find(&logs); len(logs) != 2 {
+		t.Fatalf("audit logs should be written, but got %v", len(logs))
+	}
+
+	for _, log := range logs {
+		if isClear(log.Changes) {
+			t.Errorf("masked email should not be saved in clear text in audit log, but got %v", log.Changes)
+		}
+	}
+
+	if _, body := request("GET", fmt.Sprintf("/%v/!versions/compare?from=1&to=2", contact.ID), "", ""); isClear(body) {
+		t.Errorf("masked email should not be shown in clear text when comparing versions, but got %v", body)
+	}
+
+	if status, _ := request("PATCH", fmt.Sprintf("/%v.json", contact.ID), "application/json-patch+json", `[{"op": "test", "path": "/Email", "value": "jinzhu2@example.com"}]`); status != admin.HTTPUnprocessableEntity {
+		t.Errorf("masked email should not be tested with its clear value, but got status %v", status)
+	}
+
+	if status, body := request("PATCH", fmt.Sprintf("/%v.json", contact.ID), "application/json-patch+json", `[{"op": "copy", "from": "/Email", "path": "/Name"}]`); status != http.StatusOK || isClear(body) {
+		t.Errorf("masked email should be copied as masked value, but got status %v, %v", status, body)
+	}
+
+	var result MaskedContact
+	if maskDB.First(&result, contact.ID); isClear(result.Name) || result.Email != "jinzhu2@example.com" {
+		t.Errorf("masked email should not be copied in clear text, but got %v, %v", result.Name, result.Email)
+	}
+
+	// masked values in versions couldn't be reverted
+	if status, body := request("POST", fmt.Sprintf("/%v/!versions/revert", contact.ID), "application/x-www-form-urlencoded", "version=1"); status >= 400 {
+		t.Fatalf("version should be reverted, but got status %v, %v", status, body)
+	}
+
+	if maskDB.First(&result, contact.ID); result.Name != "jinzhu" || result.Email != "jinzhu2@example.com" {
+		t.Errorf("masked email should keep its value after reverted, but got %v, %v", result.Name, result.Email)
+	}
+}
+
+func TestPatchRecord(t *testing.T) {
+	user := User{Name: "patch_record", Role: "admin", Age: 18}
+	db.Save(&user)
+
+	// someone else changed the record after it is loaded
+	userRes := Admin.GetResource("User")
+	userRes.AddHook(resource.BeforeSave, &resource.Hook{Name: "patch_record", Handler: func(result interface{}, context *qor.Context) error {
+		return db.Model(&User{}).Where("id = ?", user.ID).UpdateColumn("age", 30).Error
+	}})
+	defer userRes.AddHook(resource.BeforeSave, &resource.Hook{Name: "patch_record", Handler: func(interface{}, *qor.Context) error { return nil }})
+
+	patch := func(contentType, body string) int {
+		req, _ := http.NewRequest("PATCH", fmt.Sprintf("%v/admin/users/%v.json", server.URL, user.ID), strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+
+	if status := patch("application/merge-patch+json", `{"Role": "manager"}`); status != http.StatusOK {
+		t.Errorf("merge patch should be applied, but got status %v", status)
+	}
+
+	var result User
+	if db.First(&result, user.ID); result.Name != "patch_record" || result.Role != "manager" || result.Age != 30 {
+		t.Errorf("merge patch should only update patched fields, but got %v, %v, %v", result.Name, result.Role, result.Age)
+	}
+
+	if status := patch("application/json-patch+json", `[{"op": "test", "path": "/Role", "value": "admin"}, {"op": "replace", "path": "/Name", "value": "patched"}]`); status != admin.HTTPUnprocessableEntity {
+		t.Errorf("json patch with failed test should not be applied, but got status %v", status)
+	}
+
+	if status := patch("application/json-patch+json", `[{"op": "test", "path": "/Role", "value": "manager"}, {"op": "replace", "path": "/Name", "value": "patched"}]`); status != http.StatusOK {
+		t.Errorf("json patch should be applied, but got status %v", status)
+	}
+
+	if db.First(&result, user.ID); result.Name != "patched" || result.Role != "manager" {
+		t.Errorf("json patch should update patched fields, but got %v, %v", result.Name, result.Role)
+	}
+
+	// values of metas couldn't be read should not be revealed by patches
+	roleMeta := userRes.GetMeta("Role")
+	roleMeta.SetPermission(roles.Deny(roles.Read, roles.Anyone))
+	defer roleMeta.SetPermission(nil)
+
+	for _, body := range []string{
+		`[{"op": "test", "path": "/Role", "value": "manager"}]`,
+		`[{"op": "copy", "from": "/Role", "path": "/Name"}]`,
+	} {
+		if status := patch("application/json-patch+json", body); status != admin.HTTPUnprocessableEntity {
+			t.Errorf("json patch of unreadable meta should be rejected, but got status %v for %v", status, body)
+		}
+	}
+
+	if db.First(&result, user.ID); result.Name != "patched" {
+		t.Errorf("rejected json patch should not be applied, but got %v", result.Name)
+	}
+}
+
+func TestUpdateRecordWithChangeSet(t *testing.T) {
+	user := User{Name: "change_set", Role: "admin"}
+	db.Save(&user)
+
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("%v/admin/users/%v.json", server.URL, user.ID), strings.NewReader(`{"Name": "change_set", "Role": "manager"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result struct {
+		Changes []resource.Change `json:"_changes"`
+	}
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode != http.StatusOK || len(result.Changes) != 1 {
+		t.Fatalf("changes should be included in update response, but got %v, %#v", resp.StatusCode, result.Changes)
+	}
+
+	if change := result.Changes[0]; change.Path != "Role" || change.OldValue != "admin" || change.NewValue != "manager" {
+		t.Errorf("changed meta should be returned with its old and new values, but got %#v", change)
+	}
+}
+
+func TestSaveRecordAfterCommit(t *testing.T) {
+	visitDB := openTestDB(t, "save_record_after_commit")
+	adm := admin.New(&qor.Config{DB: visitDB})
+	visits := adm.AddResource(&Visit{})
+
+	var committed []string
+	visits.AddHook(resource.AfterSave, &resource.Hook{Name: "after_commit", Handler: func(result interface{}, context *qor.Context) error {
+		path := result.(*Visit).Path
+		context.AfterCommit(func() {
+			var count int64
+			visitDB.Model(&Visit{}).Where("path = ?", path).Count(&count)
+			committed = append(committed, fmt.Sprintf("%v:%v", path, count))
+		})
+
+		if path == "/invalid" {
+			return errors.New("invalid path")
+		}
+		return nil
+	}})
+
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	for _, c := range []struct {
+		method   string
+		url      string
+		path     string
+		expected string
+	}{
+		{"POST", "/admin/visits.json", "/created", "/created:1"},
+		{"POST", "/admin/visits.json?dry_run=1", "/created_dry_run", ""},
+		{"POST", "/admin/visits.json", "/invalid", ""},
+		{"PUT", "/admin/visits/1.json", "/updated", "/updated:1"},
+		{"PUT", "/admin/visits/1.json?dry_run=1", "/updated_dry_run", ""},
+		{"PUT", "/admin/visits/1.json", "/invalid", ""},
+	} {
+		committed = nil
+		req, _ := http.NewRequest(c.method, server.URL+c.url, strings.NewReader(fmt.Sprintf(`{"Path": "%v"}`, c.path)))
+		req.Header.Set("Content-Type", "application/json")
+		if resp, err := http.DefaultClient.Do(req); err != nil {
+			t.Fatal(err)
+		} else {
+			resp.Body.Close()
+		}
+
+		if strings.Join(committed, ",") != c.expected {
+			t.Errorf("callbacks of %v %v should be called after committed, expected %q, but got %q", c.method, c.url, c.expected, committed)
+		}
+	}
+}
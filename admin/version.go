@@ -0,0 +1,634 @@
+package admin
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/qor/utils"
+	"github.com/saitofun/qor/roles"
+	"gorm.io/gorm/clause"
+)
+
+// QorVersion snapshot of a record saved from a versioned resource, snapshots include nested records of `collection_edit` and `single_edit` metas,
+// values are stored like submitted forms, so a version could be reverted with the same decoding and saving process
+type QorVersion struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	ResourceName string `gorm:"uniqueIndex:idx_qor_version_number"`
+	ResourceID   string `gorm:"uniqueIndex:idx_qor_version_number"`
+	Version      int    `gorm:"uniqueIndex:idx_qor_version_number"`
+	UserID       string
+	UserName     string
+	Data         string `gorm:"size:65532"`
+}
+
+// GetData get snapshot of the version
+func (version QorVersion) GetData() (data map[string]interface{}) {
+	json.Unmarshal([]byte(version.Data), &data)
+	return
+}
+
+// configureVersioning migrate versions and snapshot records after they are saved, snapshots are taken in the same transaction with the change
+func (res *Resource) configureVersioning() {
+	if db := res.GetAdmin().DB; db != nil {
+		db.AutoMigrate(&QorVersion{})
+	}
+
+	res.AddHook(resource.AfterSave, &resource.Hook{
+		Name: "qor:versioning",
+		Handler: func(record interface{}, context *qor.Context) error {
+			return res.saveVersion(record, context)
+		},
+	})
+}
+
+// saveVersionRetries times to retry saving a version when its number has been taken by a concurrent save
+const saveVersionRetries = 3
+
+// saveVersion save a new version of the record, versions won't be saved in dry run mode,
+// version numbers are unique for the record, the version is saved again with next number if the number has been taken by a concurrent save,
+// versions are read with locking reads, so versions committed by concurrent saves are counted even if the outer transaction reads from a snapshot, e.g: repeatable read of MySQL
+func (res *Resource) saveVersion(record interface{}, context *qor.Context) error {
+	if context.DryRun {
+		return nil
+	}
+
+	data, err := json.Marshal(res.versionSnapshot(record, context))
+	if err != nil {
+		return err
+	}
+
+	version := QorVersion{ResourceName: res.ToParam(), ResourceID: res.PrimaryValueOf(record), Data: string(data)}
+	version.UserID, version.UserName = currentUserOf(context)
+
+	var (
+		db      = res.GetAdmin().dbOf(context).Session(&gorm.Session{NewDB: true})
+		locking = clause.Locking{Strength: "UPDATE"}
+	)
+
+	for retries := 0; ; retries++ {
+		// saved in a nested transaction, so the outer transaction could be continued if the number has been taken
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var last QorVersion
+			if err := tx.Clauses(locking).Where("resource_name = ? AND resource_id = ?", version.ResourceName, version.ResourceID).Order("version desc").Limit(1).Find(&last).Error; err != nil {
+				return err
+			}
+			version.ID, version.Version = 0, last.Version+1
+			return tx.Create(&version).Error
+		})
+
+		if err == nil || retries >= saveVersionRetries {
+			return err
+		}
+
+		var taken QorVersion
+		if db.Clauses(locking).Where("resource_name = ? AND resource_id = ? AND version = ?", version.ResourceName, version.ResourceID, version.Version).Limit(1).Find(&taken); taken.ID == 0 {
+			return err
+		}
+	}
+}
+
+// GetVersions get versions of the record, latest versions come first
+func (res *Resource) GetVersions(record interface{}, context *qor.Context) (versions []QorVersion, err error) {
+	err = res.GetAdmin().dbOf(context).Session(&gorm.Session{NewDB: true}).
+		Where("resource_name = ? AND resource_id = ?", res.ToParam(), res.PrimaryValueOf(record)).
+		Order("version desc").Find(&versions).Error
+	return
+}
+
+// GetVersion get version of the record with version number
+func (res *Resource) GetVersion(record interface{}, number int, context *qor.Context) (version QorVersion, err error) {
+	err = res.GetAdmin().dbOf(context).Session(&gorm.Session{NewDB: true}).
+		Where("resource_name = ? AND resource_id = ? AND version = ?", res.ToParam(), res.PrimaryValueOf(record), number).
+		First(&version).Error
+	return
+}
+
+// versionSnapshot snapshot primary fields and edit attrs of record, nested records of `collection_edit` and `single_edit` metas are snapshotted recursively,
+// associations of other metas are snapshotted as their primary values, values of masked metas are always masked as readers of versions are unknown
+func (res *Resource) versionSnapshot(record interface{}, context *qor.Context) map[string]interface{} {
+	snapshot := map[string]interface{}{}
+	for _, field := range res.PrimaryFields {
+		value, _ := field.ValueOf(reflect.ValueOf(record))
+		snapshot[field.Name] = fmt.Sprint(value)
+	}
+
+	for _, meta := range res.ConvertSectionToMetas(res.EditAttrs()) {
+		valuer := meta.GetValuer()
+		if valuer == nil {
+			continue
+		}
+
+		value := valuer(record, context)
+		if isNestedEditMeta(meta) {
+			if nested := meta.Resource.nestedVersionSnapshot(value, context); nested != nil {
+				snapshot[meta.Name] = nested
+			}
+			continue
+		}
+
+		if policy := meta.MaskPolicy; policy.ShouldMask(nil) {
+			snapshot[meta.Name] = policy.MaskValue(value)
+			continue
+		}
+		snapshot[meta.Name] = versionValue(value, context)
+	}
+	return snapshot
+}
+
+// withoutMaskedValues remove values of masked metas from snapshot, which couldn't be reverted
+func (res *Resource) withoutMaskedValues(snapshot map[string]interface{}) {
+	for _, meta := range res.ConvertSectionToMetas(res.EditAttrs()) {
+		if meta.MaskPolicy.ShouldMask(nil) {
+			delete(snapshot, meta.Name)
+			continue
+		}
+
+		if isNestedEditMeta(meta) {
+			switch value := snapshot[meta.Name].(type) {
+			case []interface{}:
+				for _, v := range value {
+					if v, ok := v.(map[string]interface{}); ok {
+						meta.Resource.withoutMaskedValues(v)
+					}
+				}
+			case map[string]interface{}:
+				meta.Resource.withoutMaskedValues(value)
+			}
+		}
+	}
+}
+
+func (res *Resource) nestedVersionSnapshot(value interface{}, context *qor.Context) interface{} {
+	reflectValue := reflect.Indirect(reflect.ValueOf(value))
+	switch reflectValue.Kind() {
+	case reflect.Slice:
+		records := []interface{}{}
+		for i := 0; i < reflectValue.Len(); i++ {
+			if record := addressableRecord(reflectValue.Index(i)); record != nil && !isNewRecord(record) {
+				records = append(records, res.versionSnapshot(record, context))
+			}
+		}
+		return records
+	case reflect.Struct:
+		if record := addressableRecord(reflectValue); record != nil && !isNewRecord(record) {
+			return res.versionSnapshot(record, context)
+		}
+	}
+	return nil
+}
+
+func isNestedEditMeta(meta *Meta) bool {
+	return meta.Resource != nil && (meta.Type == "collection_edit" || meta.Type == "single_edit")
+}
+
+// addressableRecord pointer of struct value, which is required by metas' valuers
+func addressableRecord(value reflect.Value) interface{} {
+	value = reflect.Indirect(value)
+	if !value.IsValid() || value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if !value.CanAddr() {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		return ptr.Interface()
+	}
+	return value.Addr().Interface()
+}
+
+// versionValue convert value to the form value, which could be set back with metas' setters
+func versionValue(value interface{}, context *qor.Context) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return utils.FormatTime(v, "2006-01-02 15:04:05", context)
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return ""
+		}
+		return utils.FormatTime(*v, "2006-01-02 15:04:05", context)
+	case driver.Valuer:
+		if reflectValue := reflect.ValueOf(v); reflectValue.Kind() == reflect.Ptr && reflectValue.IsNil() {
+			return ""
+		}
+		if value, err := v.Value(); err == nil {
+			return versionValue(value, context)
+		}
+		return ""
+	case []byte:
+		return string(v)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return ""
+		}
+		return versionValue(reflectValue.Elem().Interface(), context)
+	}
+
+	switch reflectValue.Kind() {
+	case reflect.Struct:
+		if record := addressableRecord(reflectValue); record != nil {
+			if isNewRecord(record) {
+				return ""
+			}
+			return primaryValueOfRecord(record)
+		}
+	case reflect.Slice, reflect.Array:
+		if reflectValue.Len() == 0 {
+			return ""
+		}
+
+		values := []interface{}{}
+		for i := 0; i < reflectValue.Len(); i++ {
+			value := versionValue(reflectValue.Index(i).Interface(), context)
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return fmt.Sprint(value)
+}
+
+func primaryValueOfRecord(record interface{}) string {
+	schema, err := gorm.Parse(record)
+	if err != nil || schema.PrioritizedPrimaryField == nil {
+		return fmt.Sprint(reflect.Indirect(reflect.ValueOf(record)).Interface())
+	}
+
+	value, _ := schema.PrioritizedPrimaryField.ValueOf(reflect.ValueOf(record))
+	return fmt.Sprint(value)
+}
+
+// RevertVersion revert record to the version, the version is decoded and saved like a submitted form, so metas' permissions, validators and processors are applied,
+// nested records that are added after the version will be destroyed, masked metas keep their current values
+func (res *Resource) RevertVersion(record interface{}, version QorVersion, context *qor.Context) error {
+	data := version.GetData()
+	if data == nil {
+		return fmt.Errorf("invalid version %v of %v", version.Version, res.Name)
+	}
+
+	res.withoutMaskedValues(data)
+	res.destroyAddedRecords(data, res.versionSnapshot(record, context))
+
+	// always save the record with current lock value, it will still be conflicted if it is changed after loaded
+	if field := res.GetLockField(); field != nil {
+		data[field.Name] = res.GetLockValue(record)
+	}
+
+	value, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	metaValues, err := resource.ConvertJSONToMetaValues(bytes.NewReader(value), res.GetMetas([]string{}))
+	if err != nil {
+		return err
+	}
+
+	if err := resource.DecodeToResource(res, record, metaValues, context).Start(); err != nil {
+		return err
+	}
+	return res.CallSave(record, context)
+}
+
+// destroyAddedRecords add `_destroy` values for nested records that exist currently but not in the version
+func (res *Resource) destroyAddedRecords(version, current map[string]interface{}) {
+	for _, meta := range res.ConvertSectionToMetas(res.EditAttrs()) {
+		if !isNestedEditMeta(meta) {
+			continue
+		}
+
+		nested := meta.Resource
+		switch currentValue := current[meta.Name].(type) {
+		case []interface{}:
+			versionRecords, _ := version[meta.Name].([]interface{})
+			for _, currentRecord := range currentValue {
+				currentRecord, _ := currentRecord.(map[string]interface{})
+				if versionRecord := nested.findSnapshot(versionRecords, currentRecord); versionRecord != nil {
+					nested.destroyAddedRecords(versionRecord, currentRecord)
+				} else {
+					versionRecords = append(versionRecords, nested.destroySnapshot(currentRecord))
+				}
+			}
+
+			if len(versionRecords) > 0 {
+				version[meta.Name] = versionRecords
+			}
+		case map[string]interface{}:
+			versionRecord, _ := version[meta.Name].(map[string]interface{})
+			if nested.findSnapshot([]interface{}{versionRecord}, currentValue) != nil {
+				nested.destroyAddedRecords(versionRecord, currentValue)
+			} else if versionRecord == nil {
+				version[meta.Name] = nested.destroySnapshot(currentValue)
+			}
+		}
+	}
+}
+
+// findSnapshot find snapshot with same primary values from snapshots
+func (res *Resource) findSnapshot(snapshots []interface{}, snapshot map[string]interface{}) map[string]interface{} {
+Snapshots:
+	for _, s := range snapshots {
+		if s, ok := s.(map[string]interface{}); ok {
+			for _, field := range res.PrimaryFields {
+				if fmt.Sprint(s[field.Name]) != fmt.Sprint(snapshot[field.Name]) {
+					continue Snapshots
+				}
+			}
+			return s
+		}
+	}
+	return nil
+}
+
+func (res *Resource) destroySnapshot(snapshot map[string]interface{}) map[string]interface{} {
+	destroy := map[string]interface{}{"_destroy": "1"}
+	for _, field := range res.PrimaryFields {
+		destroy[field.Name] = snapshot[field.Name]
+	}
+	return destroy
+}
+
+// CompareVersions compare snapshots of two versions, return changes from the first one to the second one,
+// values are addressed with their paths, e.g: `Addresses[0].Address1`
+func CompareVersions(from, to QorVersion) (changes resource.ChangeSet) {
+	var (
+		fromValues = flattenVersionData("", from.GetData())
+		toValues   = flattenVersionData("", to.GetData())
+		paths      []string
+	)
+
+	for path := range fromValues {
+		paths = append(paths, path)
+	}
+
+	for path := range toValues {
+		if _, ok := fromValues[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		oldValue, hasOld := fromValues[path]
+		newValue, hasNew := toValues[path]
+		if hasOld && hasNew && oldValue == newValue {
+			continue
+		}
+
+		change := resource.Change{Path: path, Name: path[strings.LastIndexAny(path, ".]")+1:]}
+		if hasOld {
+			change.OldValue = oldValue
+		}
+		if hasNew {
+			change.NewValue = newValue
+		}
+		changes = append(changes, change)
+	}
+	return
+}
+
+// readableChanges remove changes of metas that couldn't be read in the context, e.g: changes of `Tracks[0].Name` are removed if `Tracks` or its `Name` couldn't be read
+func (res *Resource) readableChanges(changes resource.ChangeSet, context *qor.Context) (results resource.ChangeSet) {
+	for _, change := range changes {
+		if res.canReadVersionPath(change.Path, context) {
+			results = append(results, change)
+		}
+	}
+	return
+}
+
+// canReadVersionPath check values of the path in snapshots could be read or not, values of primary fields could always be read
+func (res *Resource) canReadVersionPath(path string, context *qor.Context) bool {
+	current := res
+	for _, name := range strings.Split(path, ".") {
+		if idx := strings.Index(name, "["); idx >= 0 {
+			name = name[:idx]
+		}
+
+		var meta *Meta
+		for _, m := range current.ConvertSectionToMetas(current.EditAttrs()) {
+			if m.Name == name {
+				meta = m
+				break
+			}
+		}
+
+		if meta == nil {
+			return true
+		}
+
+		if !meta.HasPermission(roles.Read, context) {
+			return false
+		}
+
+		if !isNestedEditMeta(meta) {
+			return true
+		}
+		current = meta.Resource
+	}
+	return true
+}
+
+func flattenVersionData(prefix string, data map[string]interface{}) map[string]string {
+	values := map[string]string{}
+	for key, value := range data {
+		key = prefix + key
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for k, v := range flattenVersionData(key+".", value) {
+				values[k] = v
+			}
+		case []interface{}:
+			var scalars []string
+			for idx, v := range value {
+				if v, ok := v.(map[string]interface{}); ok {
+					for k, v := range flattenVersionData(fmt.Sprintf("%v[%v].", key, idx), v) {
+						values[k] = v
+					}
+				} else {
+					scalars = append(scalars, fmt.Sprint(v))
+				}
+			}
+
+			if len(scalars) > 0 || len(value) == 0 {
+				values[key] = strings.Join(scalars, ", ")
+			}
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return values
+}
+
+type versionsPage struct {
+	Record   interface{}
+	Versions []QorVersion
+	From, To *QorVersion
+	Changes  resource.ChangeSet
+	URL      string
+	context  *Context
+}
+
+// CanRevert check current user could revert the record or not
+func (page versionsPage) CanRevert() bool {
+	return page.context.Resource.HasPermission(roles.Update, page.context.Context)
+}
+
+// Previous get version before the version, return 0 if it is the first one
+func (page versionsPage) Previous(version QorVersion) int {
+	for _, v := range page.Versions {
+		if v.Version < version.Version {
+			return v.Version
+		}
+	}
+	return 0
+}
+
+// Versions list versions of the record
+func (ac *Controller) Versions(context *Context) {
+	page, err := loadVersionsPage(context)
+	if err != nil {
+		context.AddError(err)
+		context.Writer.WriteHeader(statusOfErrors(context.GetErrors(), http.StatusNotFound))
+	}
+	context.Execute("versions", page)
+}
+
+// CompareVersions compare versions of the record with params `from`, `to`, compare latest two versions if they are not set
+func (ac *Controller) CompareVersions(context *Context) {
+	page, err := loadVersionsPage(context)
+	if err == nil {
+		query := context.Request.URL.Query()
+		from, to := versionNumber(query.Get("from"), page.Versions, 1), versionNumber(query.Get("to"), page.Versions, 0)
+		for idx, version := range page.Versions {
+			if version.Version == from {
+				page.From = &page.Versions[idx]
+			}
+			if version.Version == to {
+				page.To = &page.Versions[idx]
+			}
+		}
+
+		if page.From == nil || page.To == nil {
+			err = gorm.ErrRecordNotFound
+		} else {
+			changes := context.Resource.readableChanges(CompareVersions(*page.From, *page.To), context.Context)
+			page.Changes = resource.MaskChangeSet(context.Resource, changes, nil)
+		}
+	}
+
+	if err != nil {
+		context.AddError(err)
+		context.Writer.WriteHeader(statusOfErrors(context.GetErrors(), http.StatusNotFound))
+	}
+	context.Execute("versions", page)
+}
+
+// RevertVersion revert record to the posted version with param `version`
+func (ac *Controller) RevertVersion(context *Context) {
+	var (
+		res       = context.Resource
+		record    interface{}
+		version   QorVersion
+		number, _ = strconv.Atoi(context.Request.FormValue("version"))
+	)
+
+	record, err := context.FindOne()
+	if err == nil {
+		version, err = res.GetVersion(record, number, context.Context)
+	}
+
+	if err == nil {
+		err = context.Transaction(func(*gorm.DB) error {
+			if err := res.RevertVersion(record, version, context.Context); err != nil {
+				return err
+			}
+			if context.DryRun {
+				return errVersionDryRun
+			}
+			return nil
+		})
+
+		if err == errVersionDryRun {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		context.AddError(err)
+		status := statusOfErrors(context.GetErrors(), HTTPUnprocessableEntity)
+		if status == http.StatusConflict {
+			context.Flash(string(context.t("qor_admin.form.conflict", "{{.Name}} has been changed by someone else, please reload it and apply your changes again", res)), "error")
+		}
+
+		context.Writer.WriteHeader(status)
+		page, _ := loadVersionsPage(context)
+		context.Execute("versions", page)
+		return
+	}
+
+	context.Flash(string(context.t("qor_admin.versions.reverted", "{{.Name}} was reverted to version {{.Version}}", map[string]interface{}{"Name": res.Name, "Version": version.Version})), "success")
+	http.Redirect(context.Writer, context.Request, context.URLFor(record, res), http.StatusFound)
+}
+
+var errVersionDryRun = errors.New("qor: dry run of reverting version")
+
+// versionNumber parse version number from param, fallback to the version number at index of versions if it is blank
+func versionNumber(param string, versions []QorVersion, index int) int {
+	if number, err := strconv.Atoi(param); err == nil {
+		return number
+	}
+
+	if index < len(versions) {
+		return versions[index].Version
+	}
+	return 0
+}
+
+func loadVersionsPage(context *Context) (*versionsPage, error) {
+	page := &versionsPage{context: context}
+	record, err := context.FindOne()
+	if err != nil {
+		return page, err
+	}
+
+	page.Record = record
+	page.URL = context.versionsURL(record)
+	page.Versions, err = context.Resource.GetVersions(record, context.Context)
+	return page, err
+}
+
+// versionsURL url of versions of the record, return blank if versioning isn't enabled
+func (context *Context) versionsURL(record interface{}, resources ...*Resource) string {
+	res := context.getResource(resources...)
+	if res == nil || !res.Config.Versioning || res.Config.Singleton {
+		return ""
+	}
+	return path.Join(context.URLFor(record, res), "!versions")
+}
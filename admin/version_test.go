@@ -0,0 +1,220 @@
+package admin_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/saitofun/qor/admin"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+	"gorm.io/gorm"
+)
+
+type Album struct {
+	gorm.Model
+	Title  string
+	Tracks []Track
+	Cover  Cover
+}
+
+type Track struct {
+	gorm.Model
+	AlbumID uint
+	Name    string
+}
+
+type Cover struct {
+	gorm.Model
+	AlbumID uint
+	Artist  string
+}
+
+func TestVersioning(t *testing.T) {
+	versionDB := openTestDB(t, "versions")
+	versionDB.AutoMigrate(&Album{}, &Track{}, &Cover{})
+
+	adm := admin.New(&admin.AdminConfig{DB: versionDB})
+	adm.AddResource(&Album{}, &admin.Config{Versioning: true})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	request := func(method, path string, form url.Values) string {
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		if resp.StatusCode >= 400 {
+			t.Fatalf("%v %v failed with status %v, got %v", method, path, resp.StatusCode, string(body))
+		}
+		return string(body)
+	}
+
+	request("POST", "/admin/albums", url.Values{
+		"QorResource.Title":          {"First"},
+		"QorResource.Tracks[0].Name": {"One"},
+		"QorResource.Cover.Artist":   {"Alice"},
+	})
+
+	var album Album
+	versionDB.Preload("Tracks").Preload("Cover").First(&album)
+	request("POST", fmt.Sprintf("/admin/albums/%v", album.ID), url.Values{
+		"QorResource.Title":          {"Second"},
+		"QorResource.Tracks[0].ID":   {fmt.Sprint(album.Tracks[0].ID)},
+		"QorResource.Tracks[0].Name": {"Uno"},
+		"QorResource.Tracks[1].Name": {"Two"},
+		"QorResource.Cover.ID":       {fmt.Sprint(album.Cover.ID)},
+		"QorResource.Cover.Artist":   {"Bob"},
+	})
+
+	var versions []admin.QorVersion
+	versionDB.Where("resource_name = ? AND resource_id = ?", "albums", fmt.Sprint(album.ID)).Order("version").Find(&versions)
+	if len(versions) != 2 || versions[0].Version != 1 || versions[1].Version != 2 {
+		t.Fatalf("a version should be saved each time the record is saved, but got %+v", versions)
+	}
+
+	if err := versionDB.Create(&admin.QorVersion{ResourceName: "albums", ResourceID: fmt.Sprint(album.ID), Version: 2}).Error; err == nil {
+		t.Errorf("version numbers should be unique for the record")
+	}
+
+	if tracks, ok := versions[1].GetData()["Tracks"].([]interface{}); !ok || len(tracks) != 2 {
+		t.Errorf("nested records should be included in version, but got %v", versions[1].Data)
+	}
+
+	if body := request("GET", fmt.Sprintf("/admin/albums/%v", album.ID), nil); !strings.Contains(body, fmt.Sprintf("/admin/albums/%v/!versions", album.ID)) {
+		t.Errorf("history link should be shown in show page, but got %v", body)
+	}
+
+	if body := request("GET", fmt.Sprintf("/admin/albums/%v/!versions", album.ID), nil); !strings.Contains(body, "Revert to this version") {
+		t.Errorf("versions should be listed with revert button, but got %v", body)
+	}
+
+	body := request("GET", fmt.Sprintf("/admin/albums/%v/!versions/compare?from=1&to=2", album.ID), nil)
+	for _, path := range []string{"Title", "Tracks[0].Name", "Tracks[1].Name", "Cover.Artist"} {
+		if !strings.Contains(body, "<td class=\"mdl-data-table__cell--non-numeric\">"+path+"</td>") {
+			t.Errorf("changed attribute %v should be shown in compare page, but got %v", path, body)
+		}
+	}
+
+	changes := admin.CompareVersions(versions[0], versions[1])
+	if change := changes.Get("Tracks[0].Name"); change == nil || change.OldValue != "One" || change.NewValue != "Uno" {
+		t.Errorf("changes of nested records should be compared, but got %+v", changes)
+	}
+
+	request("POST", fmt.Sprintf("/admin/albums/%v/!versions/revert", album.ID), url.Values{"version": {"1"}})
+
+	var reverted Album
+	versionDB.Preload("Tracks").Preload("Cover").First(&reverted, album.ID)
+	if reverted.Title != "First" || reverted.Cover.Artist != "Alice" || len(reverted.Tracks) != 1 || reverted.Tracks[0].Name != "One" {
+		t.Errorf("record should be reverted with nested records, but got %+v", reverted)
+	}
+
+	var count int64
+	if versionDB.Model(&admin.QorVersion{}).Where("resource_id = ?", fmt.Sprint(album.ID)).Count(&count); count != 3 {
+		t.Errorf("reverting should save a new version, but got %v versions", count)
+	}
+}
+
+func TestCompareVersionsWithoutReadPermission(t *testing.T) {
+	versionDB := openTestDB(t, "versions_permission")
+	versionDB.AutoMigrate(&Album{}, &Track{}, &Cover{})
+
+	adm := admin.New(&admin.AdminConfig{DB: versionDB})
+	albums := adm.AddResource(&Album{}, &admin.Config{Versioning: true})
+	albums.Meta(&admin.Meta{Name: "Title", Permission: roles.Deny(roles.Read, roles.Anyone)})
+	albums.GetMeta("Cover").Resource.Meta(&admin.Meta{Name: "Artist", Permission: roles.Deny(roles.Read, roles.Anyone)})
+	server := httptest.NewServer(adm.NewServeMux("/admin"))
+	defer server.Close()
+
+	album := Album{Title: "First", Tracks: []Track{{Name: "One"}}, Cover: Cover{Artist: "Alice"}}
+	context := &qor.Context{DB: versionDB}
+	if err := albums.CallSave(&album, context); err != nil {
+		t.Fatal(err)
+	}
+
+	album.Title, album.Tracks[0].Name, album.Cover.Artist = "Second", "Uno", "Bob"
+	if err := albums.CallSave(&album, context); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get(fmt.Sprintf("%v/admin/albums/%v/!versions/compare?from=1&to=2", server.URL, album.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	if !strings.Contains(string(body), ">Tracks[0].Name</td>") {
+		t.Errorf("changes of readable metas should be shown in compare page, but got %v", string(body))
+	}
+
+	for _, value := range []string{"First", "Second", "Alice", "Bob"} {
+		if strings.Contains(string(body), value) {
+			t.Errorf("changes of metas without read permission shouldn't be shown in compare page, but got %v", string(body))
+		}
+	}
+}
+
+func TestSaveVersionsConcurrently(t *testing.T) {
+	switch os.Getenv("TEST_DB") {
+	case "sqlite", "sqlite3":
+		t.Skip("writes of SQLite are serialized, test it with MySQL or Postgres")
+	}
+
+	db.AutoMigrate(&Album{}, &Track{}, &Cover{}, &admin.QorVersion{})
+	adm := admin.New(&admin.AdminConfig{DB: db})
+	albums := adm.AddResource(&Album{}, &admin.Config{Versioning: true})
+
+	album := Album{Title: "Concurrent"}
+	db.Create(&album)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 10)
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			context := &qor.Context{DB: db}
+			errs <- context.Transaction(func(tx *gorm.DB) error {
+				// read the record first, so snapshot of the transaction is taken before other saves committed
+				var record Album
+				if err := tx.First(&record, album.ID).Error; err != nil {
+					return err
+				}
+				record.Title = fmt.Sprint(i)
+				return albums.CallSave(&record, context)
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("versions should be saved concurrently, but got %v", err)
+		}
+	}
+
+	var versions []admin.QorVersion
+	db.Where("resource_name = ? AND resource_id = ?", "albums", fmt.Sprint(album.ID)).Order("version").Find(&versions)
+	for idx, version := range versions {
+		if version.Version != idx+1 {
+			t.Errorf("version numbers should be continuous, but got %v at %v", version.Version, idx)
+		}
+	}
+
+	if len(versions) != 10 {
+		t.Errorf("a version should be saved for each save, but got %v versions", len(versions))
+	}
+}
@@ -107,6 +107,8 @@
       let $target = $(e.target).closest(".qor-advanced-filter__delete"),
         $savedFilter = $target.closest(".qor-advanced-filter__savedfilter"),
         name = $target.data("filter-name"),
+        shared = $target.data("filter-shared") === true,
+        role = $target.attr("data-filter-role"),
         url = location.pathname,
         message = {
           confirm: "Are you sure you want to delete this saved filter?"
@@ -114,7 +116,14 @@
 
       QOR.qorConfirm(message, function(confirm) {
         if (confirm) {
-          $.get(url, $.param({ delete_saved_filter: name }))
+          $.get(
+            url,
+            $.param({
+              delete_saved_filter: name,
+              delete_saved_filter_shared: shared,
+              delete_saved_filter_role: role
+            })
+          )
             .done(function() {
               $target.closest("li").remove();
               if ($savedFilter.find("li").length === 0) {
@@ -130,6 +139,16 @@
     },
 
     start: function() {
+      let $save = this.$element.find(".qor-advanced-filter__save"),
+        $shared = this.$modal.find("#qor-advanced-filter__shared");
+
+      // filters could be shared only if there is a role to share with, and have to be shared if personal filters are unavailable
+      $shared.closest("label").toggle(!!$save.attr("data-filter-role"));
+      if ($save.attr("data-filter-personal") !== "true") {
+        $shared.prop({ checked: true, disabled: true });
+        $shared.closest("label").addClass("is-checked is-disabled");
+      }
+
       this.$modal
         .trigger("enable.qor.material")
         .on(
@@ -140,12 +159,19 @@
     },
 
     saveFilter: function() {
-      let name = this.$modal.find("#qor-advanced-filter__savename").val();
+      let name = this.$modal.find("#qor-advanced-filter__savename").val(),
+        shared = this.$modal.find("#qor-advanced-filter__shared").is(":checked");
 
       if (!name) {
         return;
       }
 
+      if (shared) {
+        this.$form.prepend(
+          `<input type="hidden" name="filter_saving_shared" value="true" />`
+        );
+      }
+
       this.$form
         .prepend(
           `<input type="hidden" name="filter_saving_name" value=${name}  />`
@@ -221,6 +247,11 @@
                         <label class="mdl-textfield__label" for="qor-advanced-filter__savename">Please enter name for this filter</label>
                     </div>
 
+                    <label class="mdl-checkbox mdl-js-checkbox" for="qor-advanced-filter__shared">
+                        <input class="mdl-checkbox__input" type="checkbox" id="qor-advanced-filter__shared">
+                        <span class="mdl-checkbox__label">Share this filter with team</span>
+                    </label>
+
                 </div>
                 <div class="mdl-card__actions">
                     <a class="mdl-button mdl-button--colored mdl-button--raised qor-advanced-filter__savefilter">Save This Filter</a>
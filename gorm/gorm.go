@@ -16,6 +16,7 @@ type (
 	Session      = gorm.Session
 	Schema       = schema.Schema
 	Relationship = schema.Relationship
+	DeletedAt    = gorm.DeletedAt // soft delete column
 )
 
 // gorm.logger.LogLevel
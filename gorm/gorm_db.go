@@ -3,6 +3,7 @@ package gorm
 import (
 	"bytes"
 	"errors"
+	"reflect"
 	"strings"
 
 	"gorm.io/gorm"
@@ -12,6 +13,12 @@ type DB = gorm.DB
 
 var Open = gorm.Open
 
+// InTransaction check if db is in a transaction
+func InTransaction(db *DB) bool {
+	committer, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok && committer != nil && !reflect.ValueOf(committer).IsNil()
+}
+
 func GetDBErrors(db *DB) (ret []error) {
 	if db.Error == nil {
 		return nil
@@ -14,8 +14,8 @@ var (
 	MediaLibraryURL = ""
 )
 
-func cropField(field *gorm.Field, db *gorm.DB) (cropped bool) {
-	fv, _ := field.ValueOf(reflect.ValueOf(db.Statement.Model))
+func cropField(field *gorm.Field, record reflect.Value, db *gorm.DB) (cropped bool) {
+	fv, _ := field.ValueOf(record)
 	fval := reflect.ValueOf(fv)
 	if fval.CanAddr() {
 		// TODO Handle scanner
@@ -89,7 +89,7 @@ func saveAndCropImage(isCreate bool) func(db *gorm.DB) {
 					newSchema, _ := gorm.Parse(record)
 					recordValue := reflect.ValueOf(record)
 					for _, field := range newSchema.Fields {
-						if cropField(field, db) {
+						if cropField(field, recordValue, db) {
 							isCropped = true
 							continue
 						}
@@ -115,16 +115,36 @@ func saveAndCropImage(isCreate bool) func(db *gorm.DB) {
 				}
 			}
 
-			// Handle Normal Field
-			for _, field := range db.Statement.Schema.Fields {
-				if cropField(field, db) && isCreate {
-					fv, _ := field.ValueOf(dbValue)
-					updateColumns[field.DBName] = fv
+			// Handle Normal Field, records might be created in batch
+			var records []reflect.Value
+			if indirectValue := reflect.Indirect(dbValue); indirectValue.Kind() == reflect.Slice {
+				for i := 0; i < indirectValue.Len(); i++ {
+					records = append(records, indirectValue.Index(i))
 				}
+			} else {
+				records = append(records, dbValue)
 			}
 
-			if db.Error == nil && len(updateColumns) != 0 {
-				db.AddError(db.Session(&gorm.Session{}).Model(dbVal).UpdateColumns(updateColumns).Error)
+			for _, record := range records {
+				var columns = map[string]interface{}{}
+				for key, value := range updateColumns {
+					columns[key] = value
+				}
+
+				for _, field := range db.Statement.Schema.Fields {
+					if cropField(field, record, db) && isCreate {
+						fv, _ := field.ValueOf(record)
+						columns[field.DBName] = fv
+					}
+				}
+
+				if db.Error == nil && len(columns) != 0 {
+					model := record.Interface()
+					if record.Kind() != reflect.Ptr && record.CanAddr() {
+						model = record.Addr().Interface()
+					}
+					db.AddError(db.Session(&gorm.Session{}).Model(model).UpdateColumns(columns).Error)
+				}
 			}
 		}
 	}
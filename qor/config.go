@@ -5,4 +5,8 @@ import "github.com/saitofun/qor/gorm"
 // Config qor config struct
 type Config struct {
 	DB *gorm.DB
+	// TenantResolver resolve tenant of context, records of resources that have a tenant column will be scoped with it
+	TenantResolver TenantResolver
+	// DBResolver resolve db for operations, e.g: qor.ReadReplicas(replica) to list records from a read replica
+	DBResolver DBResolver
 }
@@ -1,6 +1,8 @@
 package qor
 
 import (
+	stdcontext "context"
+	"fmt"
 	"net/http"
 
 	"github.com/saitofun/qor/gorm"
@@ -11,6 +13,21 @@ type CurrentUser interface {
 	DisplayName() string
 }
 
+// IdentifiableCurrentUser current user that has a stable identifier, which is used to store personal settings and to log who made changes
+type IdentifiableCurrentUser interface {
+	GetID() interface{}
+}
+
+// CurrentUserID get identifier of user, return blank if user is nil or it doesn't implement IdentifiableCurrentUser
+func CurrentUserID(user CurrentUser) string {
+	if user, ok := user.(IdentifiableCurrentUser); ok {
+		if id := user.GetID(); id != nil {
+			return fmt.Sprint(id)
+		}
+	}
+	return ""
+}
+
 // Context qor context, which is used for many qor components, used to share information between them
 type Context struct {
 	Request     *http.Request
@@ -20,7 +37,14 @@ type Context struct {
 	ResourceID  string
 	DB          *gorm.DB
 	Config      *Config
+	// Tenant tenant of current context, resolved with config's TenantResolver if it is nil, set it to AllTenants to access all tenants
+	Tenant interface{}
+	// DryRun validate-only mode, records will be decoded, validated and saved in a transaction that always be rollbacked
+	DryRun bool
 	Errors
+
+	ctx            stdcontext.Context
+	tenantResolved bool
 }
 
 // Clone clone current context
@@ -35,12 +59,50 @@ func (context *Context) Clone() *Context {
 	return &clone
 }
 
-// GetDB get db from current context
+// GetContext get standard context from current context, fallback to request's context if it hasn't been set
+func (context *Context) GetContext() stdcontext.Context {
+	if context.ctx != nil {
+		return context.ctx
+	}
+	if context.Request != nil {
+		return context.Request.Context()
+	}
+	return stdcontext.Background()
+}
+
+// SetContext set standard context into current context, db queries from `GetDB` will be cancelled with it
+func (context *Context) SetContext(ctx stdcontext.Context) {
+	context.ctx = ctx
+}
+
+// GetDB get db from current context, the db is bound with context's standard context,
+// config's DB or the one resolved with its DBResolver for writes will be used if context's DB hasn't been set
 func (context *Context) GetDB() *gorm.DB {
-	if context.DB != nil {
-		return context.DB
+	return context.getDB(DBWrite)
+}
+
+// GetReadDB get db for reads that could tolerate replication lag, e.g: listing records,
+// it is the same as GetDB if context's DB has been set, e.g: in a transaction
+func (context *Context) GetReadDB() *gorm.DB {
+	return context.getDB(DBRead)
+}
+
+func (context *Context) getDB(operation DBOperation) *gorm.DB {
+	db := context.DB
+	if db == nil && context.Config != nil {
+		db = context.Config.resolveDB(context, operation)
+	}
+
+	if db != nil {
+		if _, ok := db.Get("qor:current_user"); !ok && context.CurrentUser != nil {
+			db = db.Set("qor:current_user", context.CurrentUser)
+		}
+
+		if ctx := context.GetContext(); ctx != db.Statement.Context {
+			return db.WithContext(ctx)
+		}
 	}
-	return context.Config.DB
+	return db
 }
 
 // SetDB set db into current context
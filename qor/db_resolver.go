@@ -0,0 +1,46 @@
+package qor
+
+import (
+	"sync/atomic"
+
+	"github.com/saitofun/qor/gorm"
+)
+
+// DBOperation operation of db queries, used to route queries to different databases
+type DBOperation string
+
+const (
+	// DBRead queries that only read records and could tolerate replication lag, e.g: listing and counting records of index pages
+	DBRead DBOperation = "read"
+	// DBWrite queries that write records, or need to read the latest records
+	DBWrite DBOperation = "write"
+)
+
+// DBResolver resolve db of context for the operation, return nil to use config's DB
+type DBResolver func(context *Context, operation DBOperation) *gorm.DB
+
+// ReadReplicas resolver that route reads to replicas in turn, writes will be sent to config's DB
+func ReadReplicas(replicas ...*gorm.DB) DBResolver {
+	var next uint64
+	return func(context *Context, operation DBOperation) *gorm.DB {
+		if operation != DBRead || len(replicas) == 0 {
+			return nil
+		}
+		return replicas[(atomic.AddUint64(&next, 1)-1)%uint64(len(replicas))]
+	}
+}
+
+// resolveDB resolve db with config's DBResolver for the operation, fallback to config's DB
+func (config *Config) resolveDB(context *Context, operation DBOperation) *gorm.DB {
+	if config.DBResolver != nil {
+		if db := config.DBResolver(context, operation); db != nil {
+			return db
+		}
+	}
+	return config.DB
+}
+
+// SameDB check if dbs are sessions of the same database, e.g: a transaction and the db it began from
+func SameDB(db, other *gorm.DB) bool {
+	return db != nil && other != nil && db.Config.ConnPool == other.Config.ConnPool
+}
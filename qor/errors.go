@@ -1,9 +1,82 @@
 package qor
 
 import (
+	"errors"
 	"strings"
 )
 
+const (
+	// ErrorCodeInvalid default error code for errors that haven't a code
+	ErrorCodeInvalid = "invalid"
+	// ErrorCodeConflict error code for records that have been changed by someone else
+	ErrorCodeConflict = "conflict"
+)
+
+// FieldError is an error which could be addressed to a meta with its path, e.g: `Addresses[2].City`, and has a machine-readable code
+type FieldError interface {
+	error
+	ErrorPath() string
+	ErrorCode() string
+}
+
+// Error is a structured error that implemented FieldError
+type Error struct {
+	Path    string `json:"path" xml:"path"`
+	Code    string `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+
+	err error
+}
+
+// NewError generate a structured error with meta path, error code and message
+func NewError(path, code, message string) *Error {
+	return &Error{Path: path, Code: code, Message: message}
+}
+
+// Error show error message
+func (err Error) Error() string {
+	return err.Message
+}
+
+// ErrorPath return meta path of the error
+func (err Error) ErrorPath() string {
+	return err.Path
+}
+
+// ErrorCode return error code of the error
+func (err Error) ErrorCode() string {
+	return err.Code
+}
+
+// Unwrap return the original error if it is converted from another error
+func (err Error) Unwrap() error {
+	return err.err
+}
+
+// PrefixErrorPath prefix errors' path with given path, used to address errors of nested metas, e.g: `Addresses[2]` + `City` => `Addresses[2].City`
+func PrefixErrorPath(prefix string, err error) error {
+	var errs, results Errors
+	errs.AddError(err)
+
+	for _, e := range errs.GetErrors() {
+		result := Error{Path: prefix, Code: ErrorCodeInvalid, Message: e.Error(), err: e}
+		if fe, ok := e.(FieldError); ok {
+			if path := fe.ErrorPath(); path != "" {
+				result.Path = prefix + "." + path
+			}
+			if code := fe.ErrorCode(); code != "" {
+				result.Code = code
+			}
+		}
+		results.AddError(&result)
+	}
+
+	if results.HasError() {
+		return results
+	}
+	return nil
+}
+
 // Errors is a struct that used to hold errors array
 type Errors struct {
 	errors []error
@@ -41,6 +114,32 @@ func (errs Errors) GetErrors() []error {
 	return errs.errors
 }
 
+// Is report whether any of the errors matches target, so `errors.Is` could be used with Errors
+func (errs Errors) Is(target error) bool {
+	for _, err := range errs.errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFieldErrors return all errors as structured errors, errors that not implemented FieldError will have blank path and code `invalid`
+func (errs Errors) GetFieldErrors() []Error {
+	var results = []Error{}
+	for _, err := range errs.errors {
+		result := Error{Code: ErrorCodeInvalid, Message: err.Error()}
+		if e, ok := err.(FieldError); ok {
+			result.Path = e.ErrorPath()
+			if code := e.ErrorCode(); code != "" {
+				result.Code = code
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
 type errorsInterface interface {
 	GetErrors() []error
 }
@@ -0,0 +1,296 @@
+package resource
+
+import (
+	"container/list"
+	stdcontext "context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+// Cache cache store of records found with CallFindOne, implement it to use other stores, e.g: redis
+type Cache interface {
+	Get(key string) (value interface{}, ok bool)
+	Set(key string, value interface{})
+	// DeletePrefix delete entries whose key starts with prefix
+	DeletePrefix(prefix string)
+}
+
+// DefaultCacheSize default max entries of the in-memory LRU cache
+var DefaultCacheSize = 1000
+
+// SetCache set cache of resource, CallFindOne will read through it, use nil to disable it,
+// an in-memory LRU cache with DefaultCacheSize will be used if no cache is given
+//
+//	res.SetCache()
+//	res.SetCache(resource.NewLRUCache(100))
+func (res *Resource) SetCache(cache ...Cache) {
+	if len(cache) == 0 {
+		res.cache = NewLRUCache(DefaultCacheSize)
+		return
+	}
+	res.cache = cache[0]
+}
+
+// GetCache get cache of resource, return nil if cache is disabled
+func (res *Resource) GetCache() Cache {
+	return res.cache
+}
+
+// findOneWithCache find record with find one handler, records are read through the cache if it is enabled,
+// permission and record policies are checked again for cached records
+func (res *Resource) findOneWithCache(result interface{}, metaValues *MetaValues, context *qor.Context) error {
+	key := res.cacheKey(metaValues, context)
+	if key == "" {
+		return res.FindOneHandler(result, metaValues, context)
+	}
+
+	if value, ok := res.cache.Get(key); ok {
+		if cached := reflect.ValueOf(value); reflect.TypeOf(result).Kind() == reflect.Ptr && cached.Type() == reflect.TypeOf(result).Elem() {
+			if !res.HasPermission(roles.Read, context) {
+				return roles.ErrPermissionDenied
+			}
+			reflect.ValueOf(result).Elem().Set(cloneValue(cached))
+			return res.checkRecordPolicies(roles.Read, result, context)
+		}
+	}
+
+	if err := res.FindOneHandler(result, metaValues, context); err != nil {
+		return err
+	}
+	res.cache.Set(key, cloneValue(reflect.Indirect(reflect.ValueOf(result))).Interface())
+	return nil
+}
+
+// cloneValue deep copy value, so cached records won't be changed with found records, e.g: decoding nested metas into them
+func cloneValue(value reflect.Value) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() {
+			clone.Set(reflect.New(value.Type().Elem()))
+			clone.Elem().Set(cloneValue(value.Elem()))
+		}
+	case reflect.Slice:
+		if !value.IsNil() {
+			clone.Set(reflect.MakeSlice(value.Type(), value.Len(), value.Len()))
+			for i := 0; i < value.Len(); i++ {
+				clone.Index(i).Set(cloneValue(value.Index(i)))
+			}
+		}
+	case reflect.Map:
+		if !value.IsNil() {
+			clone.Set(reflect.MakeMapWithSize(value.Type(), value.Len()))
+			for _, key := range value.MapKeys() {
+				clone.SetMapIndex(key, cloneValue(value.MapIndex(key)))
+			}
+		}
+	case reflect.Struct:
+		clone.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if field := clone.Field(i); field.CanSet() {
+				field.Set(cloneValue(value.Field(i)))
+			}
+		}
+	default:
+		clone.Set(value)
+	}
+	return clone
+}
+
+// cacheKey generate cache key with decoded primary values of context's ResourceID, tenant, roles and how the record is loaded,
+// so cached records won't be leaked to other tenants or roles, return blank if records shouldn't be cached,
+// e.g: records found with conditions of context's db like scopes, which couldn't be identified
+func (res *Resource) cacheKey(metaValues *MetaValues, context *qor.Context) string {
+	if res.cache == nil || metaValues != nil || context == nil || context.ResourceID == "" || context.DryRun {
+		return ""
+	}
+
+	db := context.GetDB()
+	if db == nil || gorm.InTransaction(db) {
+		// uncommitted records shouldn't be cached
+		return ""
+	}
+
+	stmt := db.Statement
+	if _, ok := stmt.Clauses["WHERE"]; ok || len(stmt.Joins) > 0 {
+		return ""
+	}
+
+	var preloads []string
+	for name, conditions := range stmt.Preloads {
+		if len(conditions) > 0 {
+			return ""
+		}
+		preloads = append(preloads, name)
+	}
+	sort.Strings(preloads)
+
+	contextRoles := append([]string{}, context.Roles...)
+	sort.Strings(contextRoles)
+
+	return res.cachePrefix(context.ResourceID) + fmt.Sprintf("%v|%v|%v|%v|%v",
+		context.GetTenant(), strings.Join(contextRoles, ","), stmt.Unscoped, strings.Join(preloads, ","), strings.Join(stmt.Selects, ","))
+}
+
+// cachePrefix cache key prefix of records with the primary value
+func (res *Resource) cachePrefix(primaryValue string) string {
+	return fmt.Sprintf("%v:%v|", res.Name, EncodePrimaryValues(DecodePrimaryValues(primaryValue)...))
+}
+
+// invalidateCache delete cached entries of records, their nested records and context's ResourceID,
+// if context's db is in a transaction, they will be deleted again after it committed, so records read before committed won't be kept
+func (res *Resource) invalidateCache(result interface{}, context *qor.Context) {
+	var prefixes []string
+	if res.cache != nil && context != nil && context.ResourceID != "" {
+		prefixes = append(prefixes, res.cachePrefix(context.ResourceID))
+	}
+
+	invalidate := res.invalidateRecords(toRecords(result), context)
+	for _, prefix := range prefixes {
+		res.cache.DeletePrefix(prefix)
+	}
+
+	if context != nil {
+		context.AfterCommit(func() {
+			for _, prefix := range prefixes {
+				res.cache.DeletePrefix(prefix)
+			}
+			invalidate()
+		})
+	}
+}
+
+// invalidateRecords delete cached entries of records and nested records decoded into them, return a func to delete them again
+func (res *Resource) invalidateRecords(records []interface{}, context *qor.Context) func() {
+	var (
+		prefixes    []string
+		invalidates []func()
+	)
+
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+
+		if res.cache != nil && !res.isNewRecord(record) {
+			prefixes = append(prefixes, res.cachePrefix(res.PrimaryValueOf(record)))
+		}
+
+		for _, nested := range popNestedRecords(context, record) {
+			invalidates = append(invalidates, nested.res.invalidateRecords([]interface{}{nested.record}, context))
+		}
+	}
+
+	invalidate := func() {
+		for _, prefix := range prefixes {
+			res.cache.DeletePrefix(prefix)
+		}
+
+		for _, fc := range invalidates {
+			fc()
+		}
+	}
+	invalidate()
+	return invalidate
+}
+
+type nestedRecordsKey struct{}
+
+type nestedRecord struct {
+	res    *Resource
+	record interface{}
+}
+
+// addNestedRecord track nested record decoded into the record, so its cache will be invalidated when the record is saved
+func addNestedRecord(context *qor.Context, record interface{}, res Resourcer, nested interface{}) {
+	if context == nil || record == nil || res == nil || reflect.ValueOf(res).IsNil() || res.GetResource() == nil || !reflect.TypeOf(record).Comparable() {
+		return
+	}
+
+	nestedRecords, ok := context.GetContext().Value(nestedRecordsKey{}).(map[interface{}][]nestedRecord)
+	if !ok {
+		nestedRecords = map[interface{}][]nestedRecord{}
+		context.SetContext(stdcontext.WithValue(context.GetContext(), nestedRecordsKey{}, nestedRecords))
+	}
+	nestedRecords[record] = append(nestedRecords[record], nestedRecord{res: res.GetResource(), record: nested})
+}
+
+func popNestedRecords(context *qor.Context, record interface{}) []nestedRecord {
+	if context == nil || !reflect.TypeOf(record).Comparable() {
+		return nil
+	}
+
+	if nestedRecords, ok := context.GetContext().Value(nestedRecordsKey{}).(map[interface{}][]nestedRecord); ok {
+		results := nestedRecords[record]
+		delete(nestedRecords, record)
+		return results
+	}
+	return nil
+}
+
+// NewLRUCache initialize an in-memory cache that holds at most size entries, least recently used entries will be evicted
+func NewLRUCache(size int) Cache {
+	return &lruCache{size: size, entries: list.New(), elements: map[string]*list.Element{}}
+}
+
+type lruCache struct {
+	mutex    sync.Mutex
+	size     int
+	entries  *list.List
+	elements map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func (cache *lruCache) Get(key string) (interface{}, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.elements[key]; ok {
+		cache.entries.MoveToFront(element)
+		return element.Value.(*lruEntry).value, true
+	}
+	return nil, false
+}
+
+func (cache *lruCache) Set(key string, value interface{}) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.elements[key]; ok {
+		element.Value.(*lruEntry).value = value
+		cache.entries.MoveToFront(element)
+		return
+	}
+
+	cache.elements[key] = cache.entries.PushFront(&lruEntry{key: key, value: value})
+	for cache.size > 0 && cache.entries.Len() > cache.size {
+		cache.remove(cache.entries.Back())
+	}
+}
+
+func (cache *lruCache) DeletePrefix(prefix string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for key, element := range cache.elements {
+		if strings.HasPrefix(key, prefix) {
+			cache.remove(element)
+		}
+	}
+}
+
+func (cache *lruCache) remove(element *list.Element) {
+	cache.entries.Remove(element)
+	delete(cache.elements, element.Value.(*lruEntry).key)
+}
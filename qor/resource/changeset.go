@@ -0,0 +1,188 @@
+package resource
+
+import (
+	stdcontext "context"
+	"reflect"
+	"strings"
+
+	"github.com/saitofun/qor/qor"
+)
+
+// Change a meta's value changed when decoding meta values into a record,
+// Path addresses nested metas from the decoded record, e.g: `Addresses[1].Address1`
+type Change struct {
+	Path     string      `json:"path" xml:"path"`
+	Name     string      `json:"name" xml:"name"`
+	OldValue interface{} `json:"old" xml:"old"`
+	NewValue interface{} `json:"new" xml:"new"`
+}
+
+// ChangeSet changes of a record, computed by comparing record's values after `Initialize` with the ones after `Commit`
+type ChangeSet []Change
+
+// Get get change with path, return nil if it is not changed
+func (changeSet ChangeSet) Get(path string) *Change {
+	for idx := range changeSet {
+		if changeSet[idx].Path == path {
+			return &changeSet[idx]
+		}
+	}
+	return nil
+}
+
+// HasChanged check if meta with path has been changed
+func (changeSet ChangeSet) HasChanged(path string) bool {
+	return changeSet.Get(path) != nil
+}
+
+func (changeSet ChangeSet) prefix(path string) ChangeSet {
+	var results ChangeSet
+	for _, change := range changeSet {
+		change.Path = path + "." + change.Path
+		results = append(results, change)
+	}
+	return results
+}
+
+type changeSetsKey struct{}
+
+// GetChangeSet get change set of the record decoded with context, could be used in processors and hooks,
+// it is removed after the record saved with CallSave or CallSaveMany, so saved records won't be kept in context
+func GetChangeSet(context *qor.Context, record interface{}) ChangeSet {
+	if context == nil || record == nil || !reflect.TypeOf(record).Comparable() {
+		return nil
+	}
+
+	if changeSets, ok := context.GetContext().Value(changeSetsKey{}).(map[interface{}]ChangeSet); ok {
+		return changeSets[record]
+	}
+	return nil
+}
+
+// SetChangeSet set change set of the record into context, e.g: keep changes of a saved record for its response
+func SetChangeSet(context *qor.Context, record interface{}, changeSet ChangeSet) {
+	if context == nil || record == nil || !reflect.TypeOf(record).Comparable() {
+		return
+	}
+
+	changeSets, ok := context.GetContext().Value(changeSetsKey{}).(map[interface{}]ChangeSet)
+	if !ok {
+		changeSets = map[interface{}]ChangeSet{}
+		context.SetContext(stdcontext.WithValue(context.GetContext(), changeSetsKey{}, changeSets))
+	}
+	changeSets[record] = changeSet
+}
+
+func clearChangeSet(context *qor.Context, record interface{}) {
+	if context == nil || record == nil || !reflect.TypeOf(record).Comparable() {
+		return
+	}
+
+	if changeSets, ok := context.GetContext().Value(changeSetsKey{}).(map[interface{}]ChangeSet); ok {
+		delete(changeSets, record)
+	}
+}
+
+// snapshotMetas get values of metas that will be decoded, nested resources are excluded as they are tracked by their own processors
+func (processor *processor) snapshotMetas() map[string]interface{} {
+	var snapshot = map[string]interface{}{}
+	if processor.MetaValues == nil {
+		return snapshot
+	}
+
+	for _, metaValue := range processor.MetaValues.Values {
+		if isTrackableMetaValue(metaValue) {
+			snapshot[metaValue.Name] = processor.valueOf(metaValue.Meta)
+		}
+	}
+	return snapshot
+}
+
+// computeChangeSet compare record's values with its snapshot, changes of nested metas will be included with their paths
+func (processor *processor) computeChangeSet() ChangeSet {
+	var (
+		changeSet ChangeSet
+		checked   = map[string]bool{}
+	)
+
+	if processor.MetaValues == nil {
+		return nil
+	}
+
+	for _, metaValue := range processor.MetaValues.Values {
+		if !isTrackableMetaValue(metaValue) || checked[metaValue.Name] {
+			continue
+		}
+		checked[metaValue.Name] = true
+
+		oldValue, snapshotted := processor.snapshot[metaValue.Name]
+		newValue := processor.valueOf(metaValue.Meta)
+
+		// new records have no old values, zero values are not changes
+		if !snapshotted && (newValue == nil || reflect.ValueOf(newValue).IsZero()) {
+			continue
+		}
+
+		if !snapshotted || !reflect.DeepEqual(oldValue, newValue) {
+			changeSet = append(changeSet, Change{Path: metaValue.Name, Name: metaValue.Name, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return append(changeSet, processor.nestedChangeSet...)
+}
+
+// valueOf get meta's value from record's field directly, to avoid side effects of valuers, e.g: loading associations, valuer is used for virtual metas
+func (processor *processor) valueOf(meta Metaor) interface{} {
+	field := reflect.ValueOf(processor.Result)
+	for _, name := range strings.Split(meta.GetFieldName(), ".") {
+		for field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return nil
+			}
+			field = field.Elem()
+		}
+
+		if field.Kind() != reflect.Struct {
+			field = reflect.Value{}
+			break
+		}
+		field = field.FieldByName(name)
+	}
+
+	if field.IsValid() {
+		return snapshotValue(field.Interface())
+	}
+
+	if valuer := meta.GetValuer(); valuer != nil {
+		return snapshotValue(valuer(processor.Result, processor.Context))
+	}
+	return nil
+}
+
+func isTrackableMetaValue(metaValue *MetaValue) bool {
+	if metaValue.Meta == nil {
+		return false
+	}
+
+	if metaValue.MetaValues != nil && len(metaValue.MetaValues.Values) > 0 {
+		if res := metaValue.Meta.GetResource(); res != nil && !reflect.ValueOf(res).IsNil() {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshotValue dereference pointers, so changes of pointed values could be detected
+func snapshotValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
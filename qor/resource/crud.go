@@ -12,9 +12,12 @@ import (
 	"github.com/saitofun/qor/roles"
 )
 
-// CallFindOne call find one method
+// CallFindOne call find one method, records will be read through resource's cache if it is enabled, after find hooks will be run if found
 func (res *Resource) CallFindOne(result interface{}, metaValues *MetaValues, context *qor.Context) error {
-	return res.FindOneHandler(result, metaValues, context)
+	if err := res.findOneWithCache(result, metaValues, context); err != nil {
+		return err
+	}
+	return res.callHooks(AfterFind, result, context)
 }
 
 // CallFindMany call find many method
@@ -22,25 +25,99 @@ func (res *Resource) CallFindMany(result interface{}, context *qor.Context) erro
 	return res.FindManyHandler(result, context)
 }
 
-// CallSave call save method
+// CallSave call save method, before/after save hooks will be run in the same transaction, cached record will be invalidated,
+// change set of the record is removed after saved
 func (res *Resource) CallSave(result interface{}, context *qor.Context) error {
-	return res.SaveHandler(result, context)
+	defer clearChangeSet(context, result)
+	defer res.invalidateCache(result, context)
+	return res.dryRun(context, result, func() error {
+		return res.withTransaction(context, func() error {
+			if err := res.callHooks(BeforeSave, result, context); err != nil {
+				return err
+			}
+
+			if err := res.SaveHandler(result, context); err != nil {
+				return err
+			}
+			return res.callHooks(AfterSave, result, context)
+		}, BeforeSave, AfterSave)
+	})
 }
 
-// CallDelete call delete method
+// CallDelete call delete method, before/after delete hooks will be run in the same transaction, cached record will be invalidated
 func (res *Resource) CallDelete(result interface{}, context *qor.Context) error {
-	return res.DeleteHandler(result, context)
+	defer res.invalidateCache(result, context)
+	return res.dryRun(context, result, func() error {
+		return res.withTransaction(context, func() error {
+			if err := res.loadRecordForHooks(result, context); err != nil {
+				return err
+			}
+
+			if err := res.callHooks(BeforeDelete, result, context); err != nil {
+				return err
+			}
+
+			if err := res.DeleteHandler(result, context); err != nil {
+				return err
+			}
+			return res.callHooks(AfterDelete, result, context)
+		}, BeforeDelete, AfterDelete)
+	})
+}
+
+// errDryRun used to rollback the transaction in dry run mode
+var errDryRun = errors.New("dry run")
+
+// dryRun run fc in a transaction that always be rollbacked if context is in dry run mode,
+// primary keys of new records and lock values will be restored, so the would-be records could be returned
+func (res *Resource) dryRun(context *qor.Context, result interface{}, fc func() error) error {
+	if !context.DryRun {
+		return fc()
+	}
+
+	var restores []func()
+	for _, record := range toRecords(result) {
+		if res.isNewRecord(record) {
+			record := record
+			restores = append(restores, func() { res.resetPrimaryFields(record) })
+		}
+
+		if field := res.lockField; field != nil {
+			fieldValue := field.ReflectValueOf(reflect.ValueOf(record))
+			lockValue := reflect.New(fieldValue.Type()).Elem()
+			lockValue.Set(fieldValue)
+			restores = append(restores, func() { fieldValue.Set(lockValue) })
+		}
+	}
+
+	err := context.Transaction(func(*gorm.DB) error {
+		if err := fc(); err != nil {
+			return err
+		}
+		return errDryRun
+	})
+
+	for _, restore := range restores {
+		restore()
+	}
+
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
 }
 
-// ToPrimaryQueryParams generate query params based on primary key, multiple primary value are linked with a comma
+// ToPrimaryQueryParams generate query params based on primary key, value should be encoded with EncodePrimaryValues,
+// multiple primary value are linked with a comma, records will be scoped with context's tenant if multi-tenancy is enabled
 func (res *Resource) ToPrimaryQueryParams(value string, ctx *qor.Context) (query string, args []interface{}) {
 	if value == "" {
 		return
 	}
+	defer func() { query, args = res.withTenantCondition(query, args, ctx) }()
 
 	var (
 		stmt      = ctx.GetDB().Session(&gorm.Session{}).Statement
-		fArgs     = strings.Split(value, ",")
+		fArgs     = DecodePrimaryValues(value)
 		schema, _ = gorm.Parse(res.Value)
 	)
 
@@ -56,18 +133,19 @@ func (res *Resource) ToPrimaryQueryParams(value string, ctx *qor.Context) (query
 
 	} else if f := res.primaryField; f != nil {
 		return fmt.Sprintf("%v.%v = ?",
-			stmt.Quote(stmt.Table), stmt.Quote(f.DBName)), []interface{}{value}
+			stmt.Quote(schema.Table), stmt.Quote(f.DBName)), []interface{}{value}
 	}
 
 	return
 }
 
-// ToPrimaryQueryParamsFromMetaValue generate query params based on MetaValues
+// ToPrimaryQueryParamsFromMetaValue generate query params based on MetaValues, records will be scoped with context's tenant if multi-tenancy is enabled
 func (res *Resource) ToPrimaryQueryParamsFromMetaValue(metas *MetaValues, ctx *qor.Context) (query string, args []interface{}) {
 	var cond []string
 	if metas == nil {
 		return
 	}
+	defer func() { query, args = res.withTenantCondition(query, args, ctx) }()
 
 	stmt := ctx.GetDB().Statement
 	schema, _ := gorm.Parse(res.Value)
@@ -78,6 +156,7 @@ func (res *Resource) ToPrimaryQueryParamsFromMetaValue(metas *MetaValues, ctx *q
 			args = append(args, utils.ToString(mf.Value))
 		}
 	}
+	query = strings.Join(cond, " AND ")
 	return
 }
 
@@ -98,12 +177,21 @@ func (res *Resource) findOneHandler(result interface{}, metaValues *MetaValues,
 			if metaValues != nil {
 				if destroy := metaValues.Get("_destroy"); destroy != nil {
 					if fmt.Sprint(destroy.Value) != "0" && res.HasPermission(roles.Delete, context) {
-						context.GetDB().Delete(result, append([]interface{}{primaryQuerySQL}, primaryParams...)...)
+						if context.GetDB().First(result, append([]interface{}{primaryQuerySQL}, primaryParams...)...).Error == nil {
+							if err := res.checkRecordPolicies(roles.Delete, result, context); err != nil {
+								return err
+							}
+							context.GetDB().Delete(result, append([]interface{}{primaryQuerySQL}, primaryParams...)...)
+						}
 						return ErrProcessorSkipLeft
 					}
 				}
 			}
-			return context.GetDB().First(result, append([]interface{}{primaryQuerySQL}, primaryParams...)...).Error
+
+			if err := context.GetDB().First(result, append([]interface{}{primaryQuerySQL}, primaryParams...)...).Error; err != nil {
+				return err
+			}
+			return res.checkRecordPolicies(roles.Read, result, context)
 		}
 
 		return errors.New("failed to find")
@@ -113,10 +201,10 @@ func (res *Resource) findOneHandler(result interface{}, metaValues *MetaValues,
 
 func (res *Resource) findManyHandler(result interface{}, context *qor.Context) error {
 	if res.HasPermission(roles.Read, context) {
-		db := context.GetDB()
+		db := res.ScopeRecordPolicies(roles.Read, res.ScopeTenant(context.GetDB(), context), context)
 		if _, ok := db.Get("qor:getting_total_count"); ok {
 			_result := new(int64)
-			err := context.GetDB().Count(_result).Error
+			err := db.Count(_result).Error
 			switch result.(type) {
 			case *int:
 				*(result).(*int) = int(*_result)
@@ -125,20 +213,20 @@ func (res *Resource) findManyHandler(result interface{}, context *qor.Context) e
 			}
 			return err
 		}
-		return context.GetDB().Set("gorm:order_by_primary_key", "DESC").Find(result).Error
+		return db.Set("gorm:order_by_primary_key", "DESC").Find(result).Error
 	}
 
 	return roles.ErrPermissionDenied
 }
 
 func (res *Resource) saveHandler(result interface{}, ctx *qor.Context) error {
-	schema, _ := gorm.Parse(res.Value)
-	_, zero := schema.PrioritizedPrimaryField.ValueOf(reflect.ValueOf(res.Value))
-
-	if (zero &&
+	if (res.isNewRecord(result) &&
 		res.HasPermission(roles.Create, ctx)) || // has create permission
 		res.HasPermission(roles.Update, ctx) { // has update permission
-		return ctx.GetDB().Save(result).Error
+		if err := res.checkSavingRecordPolicies(result, ctx); err != nil {
+			return err
+		}
+		return res.saveWithLock(ctx.GetDB(), result, ctx)
 	}
 	return roles.ErrPermissionDenied
 }
@@ -148,6 +236,9 @@ func (res *Resource) deleteHandler(result interface{}, context *qor.Context) err
 		if sql, args := res.ToPrimaryQueryParams(context.ResourceID, context); sql != "" {
 			db := context.GetDB().Session(&gorm.Session{})
 			if !errors.Is(db.First(result, append([]interface{}{sql}, args...)...).Error, gorm.ErrRecordNotFound) {
+				if err := res.checkRecordPolicies(roles.Delete, result, context); err != nil {
+					return err
+				}
 				return db.Delete(result).Error
 			}
 		}
@@ -0,0 +1,310 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+// DefaultBatchSize default records count of each insert statement when saving records in batch, it could be changed for each resource with its BatchSize
+const DefaultBatchSize = 100
+
+// ErrBatchRollbacked returned with errors of failed records by CallSaveMany and CallDeleteMany, records are changed in one transaction, so none of them are changed if any of them failed
+var ErrBatchRollbacked = errors.New("none of records are changed as some of them failed")
+
+// CallSaveMany call save many method, results should be a slice or a pointer of slice, new records are inserted and existing records are updated in batches of resource's BatchSize,
+// records with lock field, tenant or partial update fields are updated one by one, so they could be checked,
+// records are saved in one transaction, if any of them failed, none of them will be saved, and errors of failed records will be addressed with their index, e.g: `[2].Name`, with ErrBatchRollbacked
+func (res *Resource) CallSaveMany(results interface{}, context *qor.Context) error {
+	defer func() {
+		for _, record := range toRecords(results) {
+			clearChangeSet(context, record)
+		}
+	}()
+	defer res.invalidateCache(results, context)
+	return res.dryRun(context, results, func() error {
+		return res.SaveManyHandler(results, context)
+	})
+}
+
+// CallDeleteMany call delete many method, results should be a slice or a pointer of slice,
+// records are deleted in one transaction, if any of them failed, none of them will be deleted, and errors of failed records will be addressed with their index, e.g: `[2]`, with ErrBatchRollbacked
+func (res *Resource) CallDeleteMany(results interface{}, context *qor.Context) error {
+	defer res.invalidateCache(results, context)
+	return res.dryRun(context, results, func() error {
+		return res.DeleteManyHandler(results, context)
+	})
+}
+
+// toRecords convert slice into pointers of its elements
+func toRecords(results interface{}) (records []interface{}) {
+	values := reflect.Indirect(reflect.ValueOf(results))
+	if values.Kind() != reflect.Slice {
+		return []interface{}{results}
+	}
+
+	for i := 0; i < values.Len(); i++ {
+		value := values.Index(i)
+		if value.Kind() != reflect.Ptr {
+			value = value.Addr()
+		}
+		records = append(records, value.Interface())
+	}
+	return
+}
+
+// toSlice convert records at given indexes into a slice, used to send them to db in one statement
+func toSlice(records []interface{}, indexes []int) interface{} {
+	slice := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(records[indexes[0]])), 0, len(indexes))
+	for _, idx := range indexes {
+		slice = reflect.Append(slice, reflect.ValueOf(records[idx]))
+	}
+	return slice.Interface()
+}
+
+func rowError(idx int, err error) error {
+	return qor.PrefixErrorPath(fmt.Sprintf("[%v]", idx), err)
+}
+
+func (res *Resource) isNewRecord(record interface{}) bool {
+	value := reflect.ValueOf(record)
+	for _, field := range res.PrimaryFields {
+		if _, zero := field.ValueOf(value); !zero {
+			return false
+		}
+	}
+	return true
+}
+
+// batchRollbacked mark errors of the batch with ErrBatchRollbacked
+func batchRollbacked(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var errs qor.Errors
+	errs.AddError(err, ErrBatchRollbacked)
+	return errs
+}
+
+// isBatchUpdatable check the record could be updated with others in one statement, records with lock field, tenant or partial update fields need their own conditions or columns
+func (res *Resource) isBatchUpdatable(record interface{}, context *qor.Context) bool {
+	if sql, _ := res.tenantCondition(context); sql != "" || res.lockField != nil {
+		return false
+	}
+	_, partial := GetUpdateFields(context, record)
+	return !partial
+}
+
+func (res *Resource) saveManyHandler(results interface{}, context *qor.Context) error {
+	var (
+		errs               qor.Errors
+		records            = toRecords(results)
+		creating, updating []int
+	)
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	for idx, record := range records {
+		if res.isNewRecord(record) {
+			creating = append(creating, idx)
+		} else {
+			updating = append(updating, idx)
+		}
+	}
+
+	// check permission once for the batch
+	if (len(creating) > 0 && !res.HasPermission(roles.Create, context)) ||
+		(len(updating) > 0 && !res.HasPermission(roles.Update, context)) {
+		return roles.ErrPermissionDenied
+	}
+
+	restoreLockValues := res.snapshotLockValues(records)
+	err := context.Transaction(func(tx *gorm.DB) error {
+		var saving = map[int]bool{}
+		for idx, record := range records {
+			if err := res.setTenant(record, context); err != nil {
+				errs.AddError(rowError(idx, err))
+			} else if err := res.checkSavingRecordPolicies(record, context); err != nil {
+				errs.AddError(rowError(idx, err))
+			} else if err := res.callHooks(BeforeSave, record, context); err != nil {
+				errs.AddError(rowError(idx, err))
+			} else {
+				saving[idx] = true
+			}
+		}
+
+		var creatingBatch, updatingBatch, updatingRows []int
+		for _, idx := range creating {
+			if saving[idx] {
+				creatingBatch = append(creatingBatch, idx)
+			}
+		}
+
+		for _, idx := range updating {
+			if !saving[idx] {
+				continue
+			} else if res.isBatchUpdatable(records[idx], context) {
+				updatingBatch = append(updatingBatch, idx)
+			} else {
+				updatingRows = append(updatingRows, idx)
+			}
+		}
+
+		batchSize := res.BatchSize
+		if batchSize <= 0 {
+			batchSize = DefaultBatchSize
+		}
+
+		// batched inserts and updates, if a batch failed, save its records one by one to find out failed records
+		saveInBatches := func(indexes []int, saveBatch, saveRow func(tx *gorm.DB, record interface{}) error) {
+			for start := 0; start < len(indexes); start += batchSize {
+				end := start + batchSize
+				if end > len(indexes) {
+					end = len(indexes)
+				}
+
+				batch := indexes[start:end]
+				if err := tx.Transaction(func(tx *gorm.DB) error {
+					return saveBatch(tx, toSlice(records, batch))
+				}); err != nil {
+					for _, idx := range batch {
+						if err := tx.Transaction(func(tx *gorm.DB) error {
+							return saveRow(tx, records[idx])
+						}); err != nil {
+							errs.AddError(rowError(idx, err))
+							saving[idx] = false
+						}
+					}
+				}
+			}
+		}
+
+		// INSERT INTO ... VALUES (...), (...)
+		saveInBatches(creatingBatch, func(tx *gorm.DB, records interface{}) error {
+			return tx.Create(records).Error
+		}, func(tx *gorm.DB, record interface{}) error {
+			return tx.Create(record).Error
+		})
+
+		// INSERT INTO ... VALUES (...), (...) ON CONFLICT (pk) DO UPDATE, the same as saving a record without selected fields, which creates the record if it is not found
+		saveInBatches(updatingBatch, func(tx *gorm.DB, records interface{}) error {
+			return tx.Save(records).Error
+		}, func(tx *gorm.DB, record interface{}) error {
+			return res.saveWithLock(tx, record, context)
+		})
+
+		for _, idx := range updatingBatch {
+			clearUpdateFields(context, records[idx])
+		}
+
+		for _, idx := range updatingRows {
+			if err := tx.Transaction(func(tx *gorm.DB) error {
+				return res.saveWithLock(tx, records[idx], context)
+			}); err != nil {
+				errs.AddError(rowError(idx, err))
+				saving[idx] = false
+			}
+		}
+
+		for idx, record := range records {
+			if saving[idx] {
+				if err := res.callHooks(AfterSave, record, context); err != nil {
+					errs.AddError(rowError(idx, err))
+				}
+			}
+		}
+
+		if errs.HasError() {
+			return errs
+		}
+		return nil
+	})
+
+	// records are rollbacked, reset their primary keys and lock values, so they could be saved again
+	if err != nil {
+		for _, idx := range creating {
+			res.resetPrimaryFields(records[idx])
+		}
+		restoreLockValues()
+	}
+	return batchRollbacked(err)
+}
+
+func (res *Resource) resetPrimaryFields(record interface{}) {
+	value := reflect.Indirect(reflect.ValueOf(record))
+	for _, field := range res.PrimaryFields {
+		if f := value.FieldByName(field.Name); f.CanSet() {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
+
+func (res *Resource) deleteManyHandler(results interface{}, context *qor.Context) error {
+	var (
+		errs    qor.Errors
+		records = toRecords(results)
+		indexes []int
+	)
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	// check permission once for the batch
+	if !res.HasPermission(roles.Delete, context) {
+		return roles.ErrPermissionDenied
+	}
+
+	for idx, record := range records {
+		if res.isNewRecord(record) {
+			errs.AddError(rowError(idx, gorm.ErrPrimaryKeyRequired))
+		}
+		indexes = append(indexes, idx)
+	}
+
+	if errs.HasError() {
+		return batchRollbacked(errs)
+	}
+
+	return batchRollbacked(context.Transaction(func(tx *gorm.DB) error {
+		for idx, record := range records {
+			if err := res.checkStoredRecordPolicies(roles.Delete, record, context); err != nil {
+				errs.AddError(rowError(idx, err))
+			} else {
+				errs.AddError(rowError(idx, res.callHooks(BeforeDelete, record, context)))
+			}
+		}
+
+		if errs.HasError() {
+			return errs
+		}
+
+		// DELETE ... WHERE pk IN (...)
+		db := res.ScopeRecordPolicies(roles.Delete, res.ScopeTenant(tx, context), context)
+		if result := db.Delete(toSlice(records, indexes)); result.Error != nil {
+			return result.Error
+		} else if sql, _ := res.tenantCondition(context); sql != "" && result.RowsAffected != int64(len(indexes)) {
+			// some records don't belong to current tenant
+			return gorm.ErrRecordNotFound
+		} else if len(res.appliedRecordPolicies(roles.Delete, context)) > 0 && result.RowsAffected != int64(len(indexes)) {
+			// some records are not permitted to be deleted
+			return roles.ErrPermissionDenied
+		}
+
+		for idx, record := range records {
+			errs.AddError(rowError(idx, res.callHooks(AfterDelete, record, context)))
+		}
+
+		if errs.HasError() {
+			return errs
+		}
+		return nil
+	}))
+}
@@ -1,12 +1,17 @@
 package resource_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/saitofun/qor/gorm"
 	"github.com/saitofun/qor/qor"
 	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/roles"
 	"github.com/saitofun/qor/utils/test_db"
 )
 
@@ -37,3 +42,947 @@ func TestResource_ToPrimaryQueryParams(t *testing.T) {
 	sql, args := res.ToPrimaryQueryParams("1,1", ctx)
 	fmt.Println(sql, args)
 }
+
+func TestResource_PrimaryValue(t *testing.T) {
+	type Translation struct {
+		Key    string `gorm:"primaryKey"`
+		Locale string `gorm:"primaryKey"`
+		Value  string
+	}
+
+	for _, values := range [][]string{
+		{"1"},
+		{"550e8400-e29b-41d4-a716-446655440000"},
+		{"a,b/c 100%", "en-US"},
+		{"~2C~", "~7E"},
+		{"", ","},
+	} {
+		if decoded := resource.DecodePrimaryValues(resource.EncodePrimaryValues(values...)); !reflect.DeepEqual(decoded, values) {
+			t.Errorf("primary values %#v should be decoded back, but got %#v", values, decoded)
+		}
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Translation{})
+	db.AutoMigrate(&Translation{})
+
+	translation := Translation{Key: "greeting,hello", Locale: "en-US", Value: "Hello"}
+	db.Create(&translation)
+	db.Create(&Translation{Key: "greeting", Locale: "hello,en-US", Value: "Hi"})
+
+	ctx := &qor.Context{Config: &qor.Config{DB: db}}
+	res := resource.New(&Translation{})
+
+	primaryValue := res.PrimaryValueOf(&translation)
+	if primaryValue != "greeting~2Chello,en-US" {
+		t.Errorf("primary value should be escaped, but got %v", primaryValue)
+	}
+
+	var result Translation
+	ctx.ResourceID = primaryValue
+	if err := res.CallFindOne(&result, nil, ctx); err != nil || result.Value != "Hello" {
+		t.Errorf("translation should be found with its primary value, but got %+v, %v", result, err)
+	}
+}
+
+func TestResource_CallFindManyWithCancelledContext(t *testing.T) {
+	type Product struct {
+		gorm.Model
+		Name string
+	}
+
+	db := test_db.NewTestDB()
+	db.AutoMigrate(&Product{})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ctx := &qor.Context{Config: &qor.Config{DB: db}}
+	ctx.SetContext(cancelled)
+
+	res := resource.New(&Product{})
+	if err := res.CallFindMany(res.NewSlice(), ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("find many should be cancelled with context, but got %v", err)
+	}
+}
+
+func TestResource_Hooks(t *testing.T) {
+	type Article struct {
+		gorm.Model
+		Title string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Article{})
+	db.AutoMigrate(&Article{})
+
+	var (
+		ctx    = &qor.Context{Config: &qor.Config{DB: db}}
+		res    = resource.New(&Article{})
+		called []string
+	)
+
+	res.AddHook(resource.BeforeSave, &resource.Hook{Name: "check_title", Handler: func(result interface{}, context *qor.Context) error {
+		called = append(called, "before_save")
+		if result.(*Article).Title == "invalid" {
+			return errors.New("invalid title")
+		}
+		return nil
+	}})
+
+	res.AddHook(resource.AfterSave, &resource.Hook{Name: "after_save", Handler: func(result interface{}, context *qor.Context) error {
+		called = append(called, "after_save")
+		if result.(*Article).Title == "rollback" {
+			return errors.New("rollback")
+		}
+		return nil
+	}})
+
+	res.AddHook(resource.AfterFind, &resource.Hook{Name: "after_find", Handler: func(result interface{}, context *qor.Context) error {
+		called = append(called, "after_find")
+		return nil
+	}})
+
+	if err := res.CallSave(&Article{Title: "invalid"}, ctx); err == nil {
+		t.Errorf("before save hook should abort saving")
+	}
+
+	if err := res.CallSave(&Article{Title: "rollback"}, ctx); err == nil {
+		t.Errorf("after save hook should abort saving")
+	}
+
+	var count int64
+	if db.Model(&Article{}).Count(&count); count != 0 {
+		t.Errorf("aborted articles should be rollbacked, but got %v articles", count)
+	}
+
+	article := Article{Title: "valid"}
+	if err := res.CallSave(&article, ctx); err != nil {
+		t.Errorf("no error should happen when save valid article, but got %v", err)
+	}
+
+	ctx.ResourceID = fmt.Sprint(article.ID)
+	if err := res.CallFindOne(&Article{}, nil, ctx); err != nil {
+		t.Errorf("no error should happen when find article, but got %v", err)
+	}
+
+	expected := "before_save before_save after_save before_save after_save after_find"
+	if strings.Join(called, " ") != expected {
+		t.Errorf("hooks should be called in order %v, but got %v", expected, strings.Join(called, " "))
+	}
+
+	res.AddHook(resource.BeforeDelete, &resource.Hook{Name: "protect", Handler: func(result interface{}, context *qor.Context) error {
+		if result.(*Article).Title == "valid" {
+			return errors.New("protected")
+		}
+		return nil
+	}})
+
+	if err := res.CallDelete(&Article{}, ctx); err == nil || err.Error() != "protected" {
+		t.Errorf("before delete hook should get the record and abort deleting, but got %v", err)
+	}
+}
+
+func TestResource_CallSaveManyAndDeleteMany(t *testing.T) {
+	type Tag struct {
+		gorm.Model
+		Name string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Tag{})
+	db.AutoMigrate(&Tag{})
+
+	var (
+		ctx = &qor.Context{Config: &qor.Config{DB: db}}
+		res = resource.New(&Tag{})
+	)
+
+	res.AddHook(resource.BeforeSave, &resource.Hook{Name: "check_name", Handler: func(result interface{}, context *qor.Context) error {
+		if result.(*Tag).Name == "" {
+			return qor.NewError("Name", "required", "name can't be blank")
+		}
+		return nil
+	}})
+
+	tags := []Tag{{Name: "go"}, {Name: ""}, {Name: "qor"}}
+	err := res.CallSaveMany(&tags, ctx)
+	if errs, ok := err.(qor.Errors); !ok {
+		t.Fatalf("should get errors when save invalid tags, but got %v", err)
+	} else if fieldErrors := errs.GetFieldErrors(); len(fieldErrors) != 2 || fieldErrors[0].Path != "[1].Name" || fieldErrors[0].Code != "required" {
+		t.Errorf("should get error of invalid row, but got %#v", fieldErrors)
+	} else if !errors.Is(err, resource.ErrBatchRollbacked) {
+		t.Errorf("should be told that none of tags are saved, but got %v", err)
+	}
+
+	var count int64
+	if db.Model(&Tag{}).Count(&count); count != 0 {
+		t.Errorf("failed batch should be rollbacked, but got %v tags", count)
+	}
+
+	tags[1].Name = "admin"
+	if err := res.CallSaveMany(&tags, ctx); err != nil {
+		t.Fatalf("no error should happen when save valid tags, but got %v", err)
+	}
+
+	if db.Model(&Tag{}).Count(&count); count != 3 {
+		t.Errorf("should saved 3 tags, but got %v", count)
+	}
+
+	tags[0].Name = "golang"
+	tags = append(tags, Tag{Name: "resource"})
+	if err := res.CallSaveMany(tags, ctx); err != nil {
+		t.Fatalf("no error should happen when create and update tags, but got %v", err)
+	}
+
+	var tag Tag
+	if db.First(&tag, tags[0].ID); tag.Name != "golang" {
+		t.Errorf("tag should be updated, but got %v", tag.Name)
+	}
+
+	res.BatchSize = 2
+	for idx := range tags {
+		tags[idx].Name += "!"
+	}
+	if err := res.CallSaveMany(tags, ctx); err != nil {
+		t.Fatalf("no error should happen when update tags in batches, but got %v", err)
+	}
+
+	var names []string
+	if db.Model(&Tag{}).Order("id").Pluck("name", &names); strings.Join(names, ",") != "golang!,admin!,qor!,resource!" {
+		t.Errorf("tags should be updated in batches, but got %v", names)
+	}
+
+	if err := res.CallDeleteMany(tags[:2], ctx); err != nil {
+		t.Fatalf("no error should happen when delete tags, but got %v", err)
+	}
+
+	if db.Model(&Tag{}).Count(&count); count != 2 {
+		t.Errorf("should left 2 tags after deleted, but got %v", count)
+	}
+}
+
+func TestResource_LockField(t *testing.T) {
+	type Page struct {
+		gorm.Model
+		Title   string
+		Version int
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Page{})
+	db.AutoMigrate(&Page{})
+
+	var (
+		ctx = &qor.Context{Config: &qor.Config{DB: db}}
+		res = resource.New(&Page{})
+	)
+	res.SetLockField("Version")
+
+	page := Page{Title: "draft"}
+	if err := res.CallSave(&page, ctx); err != nil {
+		t.Fatalf("no error should happen when create page, but got %v", err)
+	}
+
+	var editor1, editor2 Page
+	db.First(&editor1, page.ID)
+	db.First(&editor2, page.ID)
+
+	editor1.Title = "editor1"
+	if err := res.CallSave(&editor1, ctx); err != nil {
+		t.Fatalf("no error should happen when save page, but got %v", err)
+	}
+
+	if editor1.Version != 1 {
+		t.Errorf("version should be bumped after saved, but got %v", editor1.Version)
+	}
+
+	editor2.Title = "editor2"
+	err := res.CallSave(&editor2, ctx)
+	var conflictErr *resource.ConflictError
+	if !errors.As(err, &conflictErr) || !errors.Is(err, resource.ErrConflict) {
+		t.Fatalf("should get conflict error when save stale page, but got %v", err)
+	}
+
+	if editor2.Version != 0 || conflictErr.ErrorCode() != qor.ErrorCodeConflict || conflictErr.ErrorPath() != "Version" {
+		t.Errorf("conflict error should address lock field, and keep lock value, but got %v, %v", editor2.Version, conflictErr.ErrorPath())
+	}
+
+	var result Page
+	if db.First(&result, page.ID); result.Title != "editor1" {
+		t.Errorf("stale page should not overwrite others' changes, but got %v", result.Title)
+	}
+
+	// lock value posted back with the form
+	metaValues := &resource.MetaValues{Values: []*resource.MetaValue{{Name: "Version", Value: []string{res.GetLockValue(&editor1)}}}}
+	if err := resource.DecodeToResource(res, &editor2, metaValues, ctx).Start(); err != nil {
+		t.Errorf("no error should happen when decode lock value, but got %v", err)
+	}
+
+	if err := res.CallSave(&editor2, ctx); err != nil || editor2.Version != 2 {
+		t.Errorf("should save page with posted back lock value, but got %v, %v", err, editor2.Version)
+	}
+
+	// deleted records are not found, instead of conflicted
+	deleted := Page{Title: "deleted"}
+	res.CallSave(&deleted, ctx)
+	db.Unscoped().Delete(&Page{}, deleted.ID)
+	deleted.Title = "changed"
+	if err := res.CallSave(&deleted, ctx); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should get not found error when save deleted page, but got %v", err)
+	}
+
+	// saved records are rollbacked with the failed batch, their lock values should be restored to save them again
+	other := Page{Title: "other"}
+	res.CallSave(&other, ctx)
+	pages := []Page{editor2, other}
+	pages[0].Title, pages[1].Title, pages[1].Version = "batch", "stale", 10
+	if err := res.CallSaveMany(pages, ctx); !errors.Is(err, resource.ErrConflict) {
+		t.Fatalf("should get conflict error when save stale pages in batch, but got %v", err)
+	}
+
+	if pages[0].Version != 2 || pages[1].Version != 10 {
+		t.Errorf("lock values should be restored after batch rollbacked, but got %v, %v", pages[0].Version, pages[1].Version)
+	}
+
+	pages[1].Version = 0
+	if err := res.CallSaveMany(pages, ctx); err != nil || pages[0].Version != 3 {
+		t.Errorf("should save pages again after fixed stale page, but got %v, %v", err, pages[0].Version)
+	}
+}
+
+func TestResource_DryRun(t *testing.T) {
+	type Comment struct {
+		gorm.Model
+		Content string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Comment{})
+	db.AutoMigrate(&Comment{})
+
+	var (
+		ctx = &qor.Context{Config: &qor.Config{DB: db}, DryRun: true}
+		res = resource.New(&Comment{})
+	)
+
+	res.AddHook(resource.BeforeSave, &resource.Hook{Name: "check_content", Handler: func(result interface{}, context *qor.Context) error {
+		if result.(*Comment).Content == "" {
+			return errors.New("content can't be blank")
+		}
+		return nil
+	}})
+
+	if err := res.CallSave(&Comment{}, ctx); err == nil {
+		t.Errorf("errors should be returned in dry run mode")
+	}
+
+	comment := Comment{Content: "dry run"}
+	if err := res.CallSave(&comment, ctx); err != nil {
+		t.Errorf("no error should happen when save valid comment in dry run mode, but got %v", err)
+	}
+
+	if comment.ID != 0 || comment.Content != "dry run" {
+		t.Errorf("would-be record should be returned without primary key, but got %v, %v", comment.ID, comment.Content)
+	}
+
+	comments := []Comment{{Content: "dry run 1"}, {Content: "dry run 2"}}
+	if err := res.CallSaveMany(comments, ctx); err != nil {
+		t.Errorf("no error should happen when save valid comments in dry run mode, but got %v", err)
+	}
+
+	var count int64
+	if db.Model(&Comment{}).Count(&count); count != 0 {
+		t.Errorf("nothing should be saved in dry run mode, but got %v comments", count)
+	}
+}
+
+func TestResource_Tenant(t *testing.T) {
+	type Project struct {
+		gorm.Model
+		Name     string
+		TenantID uint
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Project{})
+	db.AutoMigrate(&Project{})
+
+	var (
+		ctx1 = &qor.Context{Config: &qor.Config{DB: db}, Tenant: uint(1)}
+		ctx2 = &qor.Context{Config: &qor.Config{DB: db}, Tenant: uint(2)}
+		res  = resource.New(&Project{})
+	)
+	res.SetTenantColumn("TenantID")
+
+	project1, project2 := Project{Name: "project1"}, Project{Name: "project2", TenantID: 1}
+	if err := res.CallSave(&project1, ctx1); err != nil || project1.TenantID != 1 {
+		t.Fatalf("project should be created with current tenant, but got %v, %v", err, project1.TenantID)
+	}
+
+	if err := res.CallSave(&project2, ctx2); err != nil || project2.TenantID != 2 {
+		t.Fatalf("project should be created with current tenant, but got %v, %v", err, project2.TenantID)
+	}
+
+	var projects []Project
+	if err := res.CallFindMany(&projects, ctx1); err != nil || len(projects) != 1 || projects[0].ID != project1.ID {
+		t.Errorf("should only find projects of current tenant, but got %v, %v", err, projects)
+	}
+
+	var count int64
+	if err := res.CallFindMany(&count, &qor.Context{Config: &qor.Config{DB: db.Model(&Project{}).Set("qor:getting_total_count", true)}, Tenant: uint(2)}); err != nil || count != 1 {
+		t.Errorf("should only count projects of current tenant, but got %v, %v", err, count)
+	}
+
+	ctx1.ResourceID = fmt.Sprint(project2.ID)
+	if err := res.CallFindOne(&Project{}, nil, ctx1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should not find project of other tenants, but got %v", err)
+	}
+
+	stolen := Project{Model: gorm.Model{ID: project2.ID}, Name: "stolen"}
+	if err := res.CallSave(&stolen, ctx1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should not update project of other tenants, but got %v", err)
+	}
+
+	if err := res.CallDelete(&Project{}, ctx1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should not delete project of other tenants, but got %v", err)
+	}
+
+	if err := res.CallDeleteMany([]Project{project1, project2}, ctx1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("should not delete projects of other tenants in batch, but got %v", err)
+	}
+
+	var result Project
+	if db.First(&result, project2.ID); result.Name != "project2" || result.TenantID != 2 {
+		t.Errorf("project of other tenants should not be changed, but got %v, %v", result.Name, result.TenantID)
+	}
+
+	if err := res.CallDeleteMany([]Project{project1}, ctx1); err != nil {
+		t.Errorf("no error should happen when delete projects of current tenant, but got %v", err)
+	}
+
+	// contexts without tenant could not access any projects, the resolved nil tenant is cached
+	var resolved int
+	noTenant := &qor.Context{Config: &qor.Config{DB: db, TenantResolver: func(*qor.Context) interface{} {
+		resolved++
+		return nil
+	}}}
+
+	if err := res.CallFindMany(&projects, noTenant); err != nil || len(projects) != 0 {
+		t.Errorf("should not find projects without tenant, but got %v, %v", err, projects)
+	}
+
+	if err := res.CallSave(&Project{Name: "no tenant"}, noTenant); !errors.Is(err, resource.ErrNoTenant) || !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("should not create project without tenant, but got %v", err)
+	}
+
+	if resolved != 1 {
+		t.Errorf("nil tenant should be resolved only once, but got %v", resolved)
+	}
+
+	allTenants := &qor.Context{Config: &qor.Config{DB: db}, Tenant: qor.AllTenants}
+	if err := res.CallFindMany(&projects, allTenants); err != nil || len(projects) != 1 || projects[0].ID != project2.ID {
+		t.Errorf("should find projects of all tenants, but got %v, %v", err, projects)
+	}
+
+	project3 := Project{Name: "project3", TenantID: 3}
+	if err := res.CallSave(&project3, allTenants); err != nil || project3.TenantID != 3 {
+		t.Errorf("project should be created with its own tenant, but got %v, %v", err, project3.TenantID)
+	}
+}
+
+type testAuthor uint
+
+func (author testAuthor) DisplayName() string {
+	return fmt.Sprintf("author %v", uint(author))
+}
+
+func TestResource_RecordPolicy(t *testing.T) {
+	type Article struct {
+		gorm.Model
+		Title    string
+		AuthorID uint
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Article{})
+	db.AutoMigrate(&Article{})
+
+	var (
+		editor = &qor.Context{Config: &qor.Config{DB: db}, Roles: []string{"editor"}, CurrentUser: testAuthor(1)}
+		admin  = &qor.Context{Config: &qor.Config{DB: db}, Roles: []string{"admin"}}
+		res    = resource.New(&Article{})
+	)
+
+	res.AddRecordPolicy(roles.CRUD, &resource.RecordPolicy{
+		Name:  "authored",
+		Roles: []string{"editor"},
+		Check: func(record interface{}, context *qor.Context) bool {
+			return record.(*Article).AuthorID == uint(context.CurrentUser.(testAuthor))
+		},
+		Scope: func(db *gorm.DB, context *qor.Context) *gorm.DB {
+			return db.Where("author_id = ?", uint(context.CurrentUser.(testAuthor)))
+		},
+	})
+
+	authored, others := Article{Title: "authored", AuthorID: 1}, Article{Title: "others", AuthorID: 2}
+	if err := res.CallSave(&authored, editor); err != nil {
+		t.Fatalf("editor should create authored article, but got %v", err)
+	}
+
+	if err := res.CallSave(&Article{Title: "others", AuthorID: 2}, editor); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("editor should not create others' article, but got %v", err)
+	}
+
+	if err := res.CallSave(&others, admin); err != nil {
+		t.Fatalf("policy should not be applied to admin, but got %v", err)
+	}
+
+	var articles []Article
+	if err := res.CallFindMany(&articles, editor); err != nil || len(articles) != 1 || articles[0].ID != authored.ID {
+		t.Errorf("editor should only list authored articles, but got %v, %v", err, articles)
+	}
+
+	if err := res.CallFindMany(&articles, admin); err != nil || len(articles) != 2 {
+		t.Errorf("admin should list all articles, but got %v, %v", err, articles)
+	}
+
+	editor.ResourceID = fmt.Sprint(others.ID)
+	if err := res.CallFindOne(&Article{}, nil, editor); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("editor should not find others' article, but got %v", err)
+	}
+
+	if err := res.CallDelete(&Article{}, editor); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("editor should not delete others' article, but got %v", err)
+	}
+
+	// change others' article to be authored
+	stolen := others
+	stolen.AuthorID = 1
+	if err := res.CallSave(&stolen, editor); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("editor should not update others' article, but got %v", err)
+	}
+
+	authored.AuthorID = 2
+	if err := res.CallSave(&authored, editor); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("editor should not give authored article to others, but got %v", err)
+	}
+
+	if err := res.CallDeleteMany([]Article{others}, editor); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("editor should not delete others' articles in batch, but got %v", err)
+	}
+
+	var count int64
+	if db.Model(&Article{}).Where("author_id = ?", 2).Count(&count); count != 1 {
+		t.Errorf("others' article should not be changed, but got %v", count)
+	}
+
+	editor.ResourceID = fmt.Sprint(authored.ID)
+	if err := res.CallDelete(&Article{}, editor); err != nil {
+		t.Errorf("editor should delete authored article, but got %v", err)
+	}
+}
+
+func TestResource_Cache(t *testing.T) {
+	type CachedProduct struct {
+		gorm.Model
+		Name string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&CachedProduct{})
+	db.AutoMigrate(&CachedProduct{})
+
+	res := resource.New(&CachedProduct{})
+	res.SetCache()
+
+	product := CachedProduct{Name: "product"}
+	db.Create(&product)
+
+	find := func(roles []string, db *gorm.DB) (CachedProduct, error) {
+		var result CachedProduct
+		ctx := &qor.Context{Config: &qor.Config{DB: db}, Roles: roles, ResourceID: res.PrimaryValueOf(&product)}
+		err := res.CallFindOne(&result, nil, ctx)
+		return result, err
+	}
+
+	if result, err := find(nil, db); err != nil || result.Name != "product" {
+		t.Fatalf("product should be found, but got %+v, %v", result, err)
+	}
+
+	// changed without the resource, cached record is returned
+	db.Model(&product).Update("name", "changed")
+	if result, _ := find(nil, db); result.Name != "product" {
+		t.Errorf("product should be read from cache, but got %v", result.Name)
+	}
+
+	if result, _ := find([]string{"admin"}, db); result.Name != "changed" {
+		t.Errorf("cached product shouldn't be shared with other roles, but got %v", result.Name)
+	}
+
+	if _, err := find(nil, db.Where("name = ?", "product")); err == nil {
+		t.Errorf("cached product shouldn't be shared with other scopes")
+	}
+
+	ctx := &qor.Context{Config: &qor.Config{DB: db}}
+	product.Name = "saved"
+	if err := res.CallSave(&product, ctx); err != nil {
+		t.Fatalf("product should be saved, but got %v", err)
+	}
+
+	if result, _ := find(nil, db); result.Name != "saved" {
+		t.Errorf("cached product should be invalidated after saved, but got %v", result.Name)
+	}
+
+	ctx.ResourceID = res.PrimaryValueOf(&product)
+	if err := res.CallDelete(&CachedProduct{}, ctx); err != nil {
+		t.Fatalf("product should be deleted, but got %v", err)
+	}
+
+	if _, err := find(nil, db); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("cached product should be invalidated after deleted, but got %v", err)
+	}
+
+	// records read before the outer transaction committed should be invalidated after it committed
+	other := CachedProduct{Name: "other"}
+	db.Create(&other)
+	findOther := func() CachedProduct {
+		var result CachedProduct
+		res.CallFindOne(&result, nil, &qor.Context{Config: &qor.Config{DB: db}, ResourceID: res.PrimaryValueOf(&other)})
+		return result
+	}
+
+	ctx = &qor.Context{Config: &qor.Config{DB: db}}
+	err := ctx.Transaction(func(tx *gorm.DB) error {
+		other.Name = "committed"
+		if err := res.CallSave(&other, ctx); err != nil {
+			return err
+		}
+
+		if result := findOther(); result.Name != "other" {
+			t.Errorf("uncommitted changes should not be read outside of the transaction, but got %v", result.Name)
+		}
+		return nil
+	})
+
+	if result := findOther(); err != nil || result.Name != "committed" {
+		t.Errorf("cached product should be invalidated after the transaction committed, but got %v, %v", result.Name, err)
+	}
+}
+
+func TestResource_NestedCache(t *testing.T) {
+	type CachedComment struct {
+		gorm.Model
+		CachedPostID uint
+		Content      string
+	}
+
+	type CachedPost struct {
+		gorm.Model
+		Title          string
+		CachedComments []CachedComment
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&CachedPost{}, &CachedComment{})
+	db.AutoMigrate(&CachedPost{}, &CachedComment{})
+
+	var (
+		ctx        = &qor.Context{Config: &qor.Config{DB: db.Session(&gorm.Session{FullSaveAssociations: true})}}
+		res        = resource.New(&CachedPost{})
+		commentRes = resource.New(&CachedComment{})
+		post       = CachedPost{Title: "post", CachedComments: []CachedComment{{Content: "comment"}}}
+		metaors    []resource.Metaor
+	)
+	commentRes.SetCache()
+
+	for _, meta := range []*resource.Meta{
+		{Name: "CachedComments", BaseResource: res, Resource: commentRes},
+		{Name: "ID", BaseResource: commentRes},
+		{Name: "Content", BaseResource: commentRes},
+	} {
+		meta.PreInitialize()
+		meta.Initialize()
+		metaors = append(metaors, testMetaor{meta})
+	}
+	db.Create(&post)
+
+	findComment := func() CachedComment {
+		var result CachedComment
+		commentRes.CallFindOne(&result, nil, &qor.Context{Config: &qor.Config{DB: db}, ResourceID: commentRes.PrimaryValueOf(&post.CachedComments[0])})
+		return result
+	}
+
+	if comment := findComment(); comment.Content != "comment" {
+		t.Fatalf("comment should be found, but got %v", comment.Content)
+	}
+
+	metaValues := &resource.MetaValues{Values: []*resource.MetaValue{
+		{Name: "CachedComments", Meta: metaors[0], MetaValues: &resource.MetaValues{Values: []*resource.MetaValue{
+			{Name: "ID", Meta: metaors[1], Value: fmt.Sprint(post.CachedComments[0].ID)},
+			{Name: "Content", Meta: metaors[2], Value: "changed"},
+		}}},
+	}}
+
+	var result CachedPost
+	db.Preload("CachedComments").First(&result, post.ID)
+	if err := resource.DecodeToResource(res, &result, metaValues, ctx).Start(); err != nil {
+		t.Fatalf("no error should happen when decode post, but got %v", err)
+	}
+
+	if err := res.CallSave(&result, ctx); err != nil {
+		t.Fatalf("no error should happen when save post, but got %v", err)
+	}
+
+	if comment := findComment(); comment.Content != "changed" {
+		t.Errorf("cached nested comment should be invalidated after post saved, but got %v", comment.Content)
+	}
+}
+
+func TestResource_Trash(t *testing.T) {
+	type Note struct {
+		gorm.Model
+		Content string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Note{})
+	db.AutoMigrate(&Note{})
+
+	var (
+		ctx  = &qor.Context{Config: &qor.Config{DB: db}, Roles: []string{"editor"}}
+		res  = resource.New(&Note{})
+		note = Note{Content: "note"}
+	)
+	res.Permission = roles.Allow(roles.CRUD, "editor")
+
+	if res.GetSoftDeleteField() == nil || resource.New(&struct{ ID uint }{}).GetSoftDeleteField() != nil {
+		t.Fatalf("should detect soft deletable resources")
+	}
+
+	db.Create(&note)
+	ctx.ResourceID = fmt.Sprint(note.ID)
+	if err := res.CallDelete(&Note{}, ctx); err != nil {
+		t.Fatalf("no error should happen when delete note, but got %v", err)
+	}
+
+	var notes []Note
+	if res.CallFindMany(&notes, ctx); len(notes) != 0 {
+		t.Errorf("deleted notes should not be found, but got %v", notes)
+	}
+
+	if res.ScopeTrash(db, ctx).Find(&notes); len(notes) != 1 || !res.IsDeleted(&notes[0]) {
+		t.Errorf("deleted notes should be found in trash, but got %v", notes)
+	}
+
+	if err := res.CallRestore(&Note{}, ctx); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("restore permission is not included in CRUD, but got %v", err)
+	}
+
+	if resource.New(&Note{}).HasPermission(roles.Restore, ctx) || resource.New(&Note{}).HasPermission(roles.Purge, ctx) {
+		t.Errorf("restore and purge permissions should be denied unless they are allowed explicitly")
+	}
+
+	res.Permission.Allow(roles.Restore, "editor")
+	var restored Note
+	if err := res.CallRestore(&restored, ctx); err != nil || res.IsDeleted(&restored) {
+		t.Errorf("no error should happen when restore note, but got %v", err)
+	}
+
+	if err := res.CallRestore(&Note{}, ctx); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("only deleted notes could be restored, but got %v", err)
+	}
+
+	res.Permission = roles.Allow(roles.Read, "editor").Allow(roles.Purge, "editor")
+	if err := res.CallPurge(&Note{}, ctx); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("delete permission is required to delete note permanently, but got %v", err)
+	}
+
+	res.Permission.Allow(roles.Delete, "editor")
+	if err := res.CallPurge(&Note{}, ctx); err != nil {
+		t.Errorf("no error should happen when delete note permanently, but got %v", err)
+	}
+
+	if err := db.Unscoped().First(&Note{}, note.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("note should be deleted permanently, but got %v", err)
+	}
+}
+
+func TestResource_ChangeSet(t *testing.T) {
+	type Comment struct {
+		gorm.Model
+		PostID  uint
+		Content string
+	}
+
+	type Post struct {
+		gorm.Model
+		Title    string
+		Body     *string
+		Comments []Comment
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Post{}, &Comment{})
+	db.AutoMigrate(&Post{}, &Comment{})
+
+	var (
+		ctx        = &qor.Context{Config: &qor.Config{DB: db}}
+		res        = resource.New(&Post{})
+		commentRes = resource.New(&Comment{})
+		body       = "body"
+		post       = Post{Title: "title", Body: &body, Comments: []Comment{{Content: "comment 1"}, {Content: "comment 2"}}}
+		metaors    []resource.Metaor
+	)
+
+	for _, meta := range []*resource.Meta{
+		{Name: "Title", BaseResource: res},
+		{Name: "Body", BaseResource: res},
+		{Name: "Comments", BaseResource: res, Resource: commentRes},
+		{Name: "ID", BaseResource: commentRes},
+		{Name: "Content", BaseResource: commentRes},
+	} {
+		meta.PreInitialize()
+		meta.Initialize()
+		metaors = append(metaors, testMetaor{meta})
+	}
+
+	if err := db.Create(&post).Error; err != nil {
+		t.Fatalf("no error should happen when create post, but got %v", err)
+	}
+
+	var processorChangeSet, hookChangeSet resource.ChangeSet
+	res.AddProcessor(&resource.Processor{Name: "change_set", Handler: func(record interface{}, metaValues *resource.MetaValues, context *qor.Context) error {
+		processorChangeSet = resource.GetChangeSet(context, record)
+		return nil
+	}})
+	res.AddHook(resource.BeforeSave, &resource.Hook{Name: "change_set", Handler: func(result interface{}, context *qor.Context) error {
+		hookChangeSet = resource.GetChangeSet(context, result)
+		return nil
+	}})
+
+	metaValues := &resource.MetaValues{Values: []*resource.MetaValue{
+		{Name: "ID", Meta: metaors[3], Value: fmt.Sprint(post.ID)},
+		{Name: "Title", Meta: metaors[0], Value: "title"},
+		{Name: "Body", Meta: metaors[1], Value: "new body"},
+		{Name: "Comments", Meta: metaors[2], Index: 0, MetaValues: &resource.MetaValues{Values: []*resource.MetaValue{
+			{Name: "ID", Meta: metaors[3], Value: fmt.Sprint(post.Comments[0].ID)},
+			{Name: "Content", Meta: metaors[4], Value: "comment 1"},
+		}}},
+		{Name: "Comments", Meta: metaors[2], Index: 1, MetaValues: &resource.MetaValues{Values: []*resource.MetaValue{
+			{Name: "ID", Meta: metaors[3], Value: fmt.Sprint(post.Comments[1].ID)},
+			{Name: "Content", Meta: metaors[4], Value: "new comment 2"},
+		}}},
+		{Name: "Comments", Meta: metaors[2], Index: 2, MetaValues: &resource.MetaValues{Values: []*resource.MetaValue{
+			{Name: "Content", Meta: metaors[4], Value: "comment 3"},
+		}}},
+	}}
+
+	var result Post
+	db.Preload("Comments").First(&result, post.ID)
+	if err := resource.DecodeToResource(res, &result, metaValues, ctx).Start(); err != nil {
+		t.Fatalf("no error should happen when decode post, but got %v", err)
+	}
+
+	changeSet := resource.GetChangeSet(ctx, &result)
+	if err := res.CallSave(&result, ctx); err != nil {
+		t.Fatalf("no error should happen when save post, but got %v", err)
+	}
+
+	if resource.GetChangeSet(ctx, &result) != nil {
+		t.Errorf("change set should be removed after saved")
+	}
+
+	if len(changeSet) != 3 || len(processorChangeSet) != 3 || len(hookChangeSet) != 3 {
+		t.Fatalf("change set should be available to processors and hooks, but got %#v, %#v, %#v", changeSet, processorChangeSet, hookChangeSet)
+	}
+
+	if change := changeSet.Get("Body"); change == nil || change.Name != "Body" || change.OldValue != "body" || change.NewValue != "new body" {
+		t.Errorf("changes of pointer fields should be detected, but got %#v", change)
+	}
+
+	if changeSet.HasChanged("Title") || changeSet.HasChanged("Comments[0].Content") {
+		t.Errorf("unchanged metas should not be included, but got %#v", changeSet)
+	}
+
+	if change := changeSet.Get("Comments[1].Content"); change == nil || change.OldValue != "comment 2" || change.NewValue != "new comment 2" {
+		t.Errorf("changes of nested collections should be included, but got %#v", change)
+	}
+
+	if change := changeSet.Get("Comments[2].Content"); change == nil || change.OldValue != nil || change.NewValue != "comment 3" {
+		t.Errorf("values of new nested records should be included, but got %#v", change)
+	}
+}
+
+func TestResource_UpdateFields(t *testing.T) {
+	type Note struct {
+		gorm.Model
+		Title string
+		Body  string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Note{})
+	db.AutoMigrate(&Note{})
+
+	var (
+		ctx   = &qor.Context{Config: &qor.Config{DB: db}}
+		res   = resource.New(&Note{})
+		note1 = Note{Title: "title1", Body: "body1"}
+		note2 = Note{Title: "title2", Body: "body2"}
+	)
+	db.Create(&note1)
+	db.Create(&note2)
+
+	note1.Title, note1.Body = "new title1", "new body1"
+	note2.Title, note2.Body = "new title2", "new body2"
+	resource.SetUpdateFields(ctx, &note1, []string{"Title"})
+
+	// update fields only limit the record they are set for
+	if err := res.CallSave(&note2, ctx); err != nil {
+		t.Fatalf("no error should happen when save note, but got %v", err)
+	}
+
+	if err := res.CallSave(&note1, ctx); err != nil {
+		t.Fatalf("no error should happen when save note, but got %v", err)
+	}
+
+	var result1, result2 Note
+	db.First(&result1, note1.ID)
+	db.First(&result2, note2.ID)
+	if result1.Title != "new title1" || result1.Body != "body1" || result2.Title != "new title2" || result2.Body != "new body2" {
+		t.Errorf("only update fields of the record should be saved, but got %+v, %+v", result1, result2)
+	}
+
+	// update fields are cleared after the record saved
+	if _, ok := resource.GetUpdateFields(ctx, &note1); ok {
+		t.Errorf("update fields should be cleared after the record saved")
+	}
+}
+
+func TestResource_SavePermission(t *testing.T) {
+	type Memo struct {
+		gorm.Model
+		Content string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Memo{})
+	db.AutoMigrate(&Memo{})
+
+	var (
+		creator = &qor.Context{Config: &qor.Config{DB: db}, Roles: []string{"creator"}}
+		res     = resource.New(&Memo{})
+		memo    = Memo{Content: "memo"}
+	)
+	res.Permission = roles.Allow(roles.Create, "creator").Allow(roles.Read, "creator").Allow(roles.Update, "editor")
+
+	if err := res.CallSave(&memo, creator); err != nil {
+		t.Fatalf("no error should happen when create memo with create permission, but got %v", err)
+	}
+
+	memo.Content = "changed"
+	if err := res.CallSave(&memo, creator); !errors.Is(err, roles.ErrPermissionDenied) {
+		t.Errorf("should not update memo without update permission, but got %v", err)
+	}
+}
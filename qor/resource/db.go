@@ -0,0 +1,44 @@
+package resource
+
+import (
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+)
+
+// SetDB bind resource to db, records of the resource will be found from and saved into it instead of config's DB, e.g: an analytics database
+func (res *Resource) SetDB(db *gorm.DB) {
+	res.DB = db
+}
+
+// SetDBResolver set db resolver of resource, e.g: qor.ReadReplicas(replica) to list records from a read replica
+func (res *Resource) SetDBResolver(resolver qor.DBResolver) {
+	res.DBResolver = resolver
+}
+
+// ContextWithDB clone context with resource's DB and DBResolver, context is returned as it is if the resource doesn't have them,
+// context's DB is kept if it is a session of resource's DB, e.g: a transaction, otherwise it will be resolved again
+func (res *Resource) ContextWithDB(context *qor.Context) *qor.Context {
+	if context == nil || (res.DB == nil && res.DBResolver == nil) {
+		return context
+	}
+
+	config := qor.Config{}
+	if context.Config != nil {
+		config = *context.Config
+	}
+
+	if res.DB != nil {
+		config.DB = res.DB
+	}
+
+	if res.DBResolver != nil {
+		config.DBResolver = res.DBResolver
+	}
+
+	clone := *context
+	clone.Config = &config
+	if clone.DB != nil && res.DB != nil && !qor.SameDB(clone.DB, res.DB) {
+		clone.DB = nil
+	}
+	return &clone
+}
@@ -0,0 +1,96 @@
+package resource
+
+import (
+	"reflect"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+)
+
+// HookKind kind of resource's lifecycle hooks
+type HookKind string
+
+const (
+	// BeforeSave hooks will be run before save handler, in the same transaction
+	BeforeSave HookKind = "before_save"
+	// AfterSave hooks will be run after save handler, in the same transaction
+	AfterSave HookKind = "after_save"
+	// BeforeDelete hooks will be run before delete handler, in the same transaction
+	BeforeDelete HookKind = "before_delete"
+	// AfterDelete hooks will be run after delete handler, in the same transaction
+	AfterDelete HookKind = "after_delete"
+	// AfterFind hooks will be run after find one handler
+	AfterFind HookKind = "after_find"
+)
+
+// Hook lifecycle hook struct, return an error from its handler will abort the operation and rollback the change
+// hooks are also run in dry run mode, check `context.DryRun` to skip side effects like sending emails
+type Hook struct {
+	Name    string
+	Handler Handler
+}
+
+// AddHook add lifecycle hook to resource, hooks will be run in the order they are added, hook with same name will be replaced
+func (res *Resource) AddHook(kind HookKind, hook *Hook) {
+	if res.hooks == nil {
+		res.hooks = map[HookKind][]*Hook{}
+	}
+
+	for idx, h := range res.hooks[kind] {
+		if h.Name == hook.Name {
+			res.hooks[kind][idx] = hook
+			return
+		}
+	}
+	res.hooks[kind] = append(res.hooks[kind], hook)
+}
+
+// GetHooks get registered lifecycle hooks with kind
+func (res *Resource) GetHooks(kind HookKind) []*Hook {
+	return res.hooks[kind]
+}
+
+func (res *Resource) callHooks(kind HookKind, result interface{}, context *qor.Context) error {
+	for _, hook := range res.hooks[kind] {
+		if err := hook.Handler(result, context); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withTransaction run fc in a transaction if any hooks with given kinds registered
+func (res *Resource) withTransaction(context *qor.Context, fc func() error, kinds ...HookKind) error {
+	var hasHooks bool
+	for _, kind := range kinds {
+		if len(res.hooks[kind]) > 0 {
+			hasHooks = true
+		}
+	}
+
+	if !hasHooks {
+		return fc()
+	}
+
+	return context.Transaction(func(*gorm.DB) error {
+		return fc()
+	})
+}
+
+// loadRecordForHooks load the record going to be deleted, so delete hooks could check it, e.g: log values of the deleted record
+func (res *Resource) loadRecordForHooks(result interface{}, context *qor.Context) error {
+	if (len(res.hooks[BeforeDelete]) == 0 && len(res.hooks[AfterDelete]) == 0) || context.ResourceID == "" {
+		return nil
+	}
+
+	if schema, err := gorm.Parse(result); err == nil && schema.PrioritizedPrimaryField != nil {
+		if _, zero := schema.PrioritizedPrimaryField.ValueOf(reflect.ValueOf(result)); !zero {
+			return nil
+		}
+	}
+
+	if sql, args := res.ToPrimaryQueryParams(context.ResourceID, context); sql != "" {
+		return context.GetDB().First(result, append([]interface{}{sql}, args...)...).Error
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package resource
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+// JSONSchemaDraft dialect of generated JSON schemas
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema JSON schema document
+type JSONSchema map[string]interface{}
+
+// ConfigureJSONSchemaInterface if a meta implemented this interface, it will be called to configure its generated JSON schema
+type ConfigureJSONSchemaInterface interface {
+	ConfigureJSONSchema(schema JSONSchema, mode roles.PermissionMode, context *qor.Context) JSONSchema
+}
+
+// JSONSchemaOf generate JSON schema of resource from its metas, metas without permission of mode will be excluded,
+// use roles.Create, roles.Update to get schemas of payloads, roles.Read to get schema of responses
+func JSONSchemaOf(res Resourcer, mode roles.PermissionMode, context *qor.Context) JSONSchema {
+	schema := jsonSchemaOfMetas(res, res.GetMetas([]string{}), mode, context, map[Resourcer]bool{})
+	schema["$schema"] = JSONSchemaDraft
+	if name := res.GetResource().Name; name != "" {
+		schema["title"] = name
+	}
+	return schema
+}
+
+func jsonSchemaOfMetas(res Resourcer, metaors []Metaor, mode roles.PermissionMode, context *qor.Context, visiting map[Resourcer]bool) JSONSchema {
+	var (
+		properties = JSONSchema{}
+		required   []string
+		recordType = reflect.Indirect(reflect.ValueOf(res.GetResource().Value)).Type()
+		validators = res.GetResource().Validators
+	)
+
+	visiting[res] = true
+	defer delete(visiting, res)
+
+	for _, metaor := range metaors {
+		if !metaor.HasPermission(mode, context) {
+			continue
+		}
+
+		field, hasField := fieldByName(recordType, metaor.GetFieldName())
+		if !hasField && metaor.GetValuer() == nil && metaor.GetSetter() == nil {
+			continue
+		}
+
+		var property = JSONSchema{}
+		if hasField {
+			property = JSONSchemaOfType(field.Type)
+		}
+
+		if nestedRes := metaor.GetResource(); nestedRes != nil && !reflect.ValueOf(nestedRes).IsNil() && hasField && isRecordType(field.Type) {
+			if visiting[nestedRes] {
+				property = JSONSchema{"type": "object"}
+			} else {
+				property = jsonSchemaOfMetas(nestedRes, metaor.GetMetas(), mode, context, visiting)
+			}
+
+			if fieldType := indirectType(field.Type); fieldType.Kind() == reflect.Slice {
+				property = JSONSchema{"type": "array", "items": property}
+			}
+		}
+
+		if hasField {
+			for _, option := range strings.Split(field.Tag.Get("valid"), ",") {
+				if isRequired := applyValidOption(property, option); isRequired && mode != roles.Read {
+					required = append(required, metaor.GetName())
+				}
+			}
+		}
+
+		if configurer, ok := metaor.(ConfigureJSONSchemaInterface); ok {
+			property = configurer.ConfigureJSONSchema(property, mode, context)
+		}
+
+		if mode != roles.Read && !containsString(required, metaor.GetName()) {
+			for _, validator := range validators {
+				if containsString(validator.Required, metaor.GetName()) {
+					required = append(required, metaor.GetName())
+					break
+				}
+			}
+		}
+		properties[metaor.GetName()] = property
+	}
+
+	schema := JSONSchema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// JSONSchemaOfType generate JSON schema from go type, pointers are nullable
+func JSONSchemaOfType(typ reflect.Type) JSONSchema {
+	var nullable bool
+	for typ.Kind() == reflect.Ptr {
+		typ, nullable = typ.Elem(), true
+	}
+
+	var schema = JSONSchema{}
+	switch {
+	case typ == reflect.TypeOf(time.Time{}) || typ == reflect.TypeOf(sql.NullTime{}):
+		schema["type"], schema["format"] = "string", "date-time"
+	case isCustomizedType(typ):
+		// customized values could be anything
+		return schema
+	default:
+		switch typ.Kind() {
+		case reflect.String:
+			schema["type"] = "string"
+		case reflect.Bool:
+			schema["type"] = "boolean"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			schema["type"] = "integer"
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schema["type"], schema["minimum"] = "integer", 0
+		case reflect.Float32, reflect.Float64:
+			schema["type"] = "number"
+		case reflect.Slice, reflect.Array:
+			if typ.Elem().Kind() == reflect.Uint8 {
+				schema["type"], schema["contentEncoding"] = "string", "base64"
+			} else {
+				schema["type"], schema["items"] = "array", JSONSchemaOfType(typ.Elem())
+			}
+		case reflect.Map, reflect.Struct:
+			schema["type"] = "object"
+		default:
+			return schema
+		}
+	}
+
+	if nullable {
+		schema["type"] = []interface{}{schema["type"], "null"}
+	}
+	return schema
+}
+
+var validLengthRegexp = regexp.MustCompile(`^(?:length|stringlength|runelength)\((\d+)\|(\d+)\)$`)
+
+// applyValidOption apply govalidator's option from `valid` tag to schema, return true if it is required
+func applyValidOption(schema JSONSchema, option string) (required bool) {
+	// remove customized error messages, e.g: `required~Name can't be blank`
+	option = strings.TrimSpace(strings.SplitN(option, "~", 2)[0])
+
+	switch option {
+	case "required":
+		return true
+	case "email":
+		schema["format"] = "email"
+	case "url", "requrl":
+		schema["format"] = "uri"
+	case "uuid":
+		schema["format"] = "uuid"
+	case "ipv4", "ipv6":
+		schema["format"] = option
+	default:
+		if matches := validLengthRegexp.FindStringSubmatch(option); len(matches) == 3 {
+			min, _ := strconv.Atoi(matches[1])
+			max, _ := strconv.Atoi(matches[2])
+			schema["minLength"], schema["maxLength"] = min, max
+		}
+	}
+	return false
+}
+
+// isCustomizedType check if values of type are customized when encoding or saving, e.g: media files, times
+func isCustomizedType(typ reflect.Type) bool {
+	return reflect.PtrTo(typ).Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) ||
+		(typ.Kind() == reflect.Struct && reflect.PtrTo(typ).Implements(reflect.TypeOf((*driver.Valuer)(nil)).Elem()))
+}
+
+// isRecordType check if type is a struct or a slice of struct that could be decoded with nested resource
+func isRecordType(typ reflect.Type) bool {
+	if typ = indirectType(typ); typ.Kind() == reflect.Slice {
+		typ = indirectType(typ.Elem())
+	}
+	return typ.Kind() == reflect.Struct && typ != reflect.TypeOf(time.Time{}) && !isCustomizedType(typ)
+}
+
+func fieldByName(typ reflect.Type, fieldName string) (field reflect.StructField, ok bool) {
+	if fieldName == "" {
+		return field, false
+	}
+
+	for _, name := range strings.Split(fieldName, ".") {
+		if typ = indirectType(typ); typ.Kind() != reflect.Struct {
+			return field, false
+		}
+
+		if field, ok = typ.FieldByName(name); !ok {
+			return field, false
+		}
+		typ = field.Type
+	}
+	return field, true
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,233 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/utils"
+)
+
+// ErrConflict record has been changed by someone else since it was loaded
+var ErrConflict = errors.New("record has been changed by someone else, please reload it and try again")
+
+// ConflictError returned when saving a record whose lock field doesn't match the one in database, check it with errors.As
+type ConflictError struct {
+	Resource *Resource
+	Record   interface{}
+	Field    string
+}
+
+// Error return conflict error message
+func (err *ConflictError) Error() string {
+	return fmt.Sprintf("%v %v", err.Resource.Name, ErrConflict.Error())
+}
+
+// ErrorPath return lock field name
+func (err *ConflictError) ErrorPath() string {
+	return err.Field
+}
+
+// ErrorCode return error code `conflict`
+func (*ConflictError) ErrorCode() string {
+	return qor.ErrorCodeConflict
+}
+
+// Unwrap make errors.Is(err, ErrConflict) work
+func (*ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// SetLockField enable optimistic locking with a version field (integer) or an updated at field (time),
+// the field's value need to be posted back when updating the record, the record won't be saved if it has been changed since then
+func (res *Resource) SetLockField(name string) {
+	schema, err := gorm.Parse(res.Value)
+	if err != nil {
+		utils.ExitWithMsg(err)
+	}
+
+	field, ok := schema.FieldsByName[name]
+	if !ok {
+		utils.ExitWithMsg(fmt.Sprintf("lock field %v not found for resource %v", name, res.Name))
+	}
+	res.lockField = field
+
+	res.AddProcessor(&Processor{
+		Name: "qor:lock_field",
+		Handler: func(record interface{}, metaValues *MetaValues, context *qor.Context) error {
+			if metaValue := metaValues.Get(field.Name); metaValue != nil && metaValue.Value != nil {
+				return setLockValue(field, record, metaValue.Value)
+			}
+			return nil
+		},
+	})
+}
+
+// GetLockField get field used for optimistic locking, return nil if not enabled
+func (res *Resource) GetLockField() *gorm.Field {
+	return res.lockField
+}
+
+// GetLockValue get lock value of record as string, times are formatted as RFC3339 with nanoseconds to keep their precision
+func (res *Resource) GetLockValue(record interface{}) string {
+	if res.lockField == nil {
+		return ""
+	}
+
+	value, _ := res.lockField.ValueOf(reflect.ValueOf(record))
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v != nil {
+			return v.Format(time.RFC3339Nano)
+		}
+		return ""
+	}
+	return utils.ToString(value)
+}
+
+// snapshotLockValues snapshot lock values of records, return a func to restore them, e.g: records' versions are bumped but not saved
+func (res *Resource) snapshotLockValues(records []interface{}) func() {
+	if res.lockField == nil {
+		return func() {}
+	}
+
+	var restores []func()
+	for _, record := range records {
+		fieldValue := res.lockField.ReflectValueOf(reflect.ValueOf(record))
+		if fieldValue.CanSet() {
+			originalValue := reflect.New(fieldValue.Type()).Elem()
+			originalValue.Set(fieldValue)
+			restores = append(restores, func() { fieldValue.Set(originalValue) })
+		}
+	}
+
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+func setLockValue(field *gorm.Field, record interface{}, value interface{}) error {
+	str, isString := value.(string)
+	if !isString {
+		if values, ok := value.([]string); ok && len(values) > 0 {
+			str, isString = values[0], true
+		}
+	}
+
+	if isString {
+		if str == "" {
+			return nil
+		}
+
+		if t, err := time.Parse(time.RFC3339Nano, str); err == nil {
+			return field.Set(reflect.ValueOf(record), t)
+		}
+		return field.Set(reflect.ValueOf(record), str)
+	}
+	return field.Set(reflect.ValueOf(record), value)
+}
+
+// saveWithLock save record, if lock field is enabled, update it only when its lock value is not changed,
+// if update fields of the record are set in context, e.g: partial updates, only these fields will be saved,
+// if multi-tenancy is enabled, record will be saved into context's tenant, and only records of the tenant could be updated
+func (res *Resource) saveWithLock(db *gorm.DB, record interface{}, context *qor.Context) error {
+	defer clearUpdateFields(context, record)
+
+	if err := res.setTenant(record, context); err != nil {
+		return err
+	}
+
+	if res.isNewRecord(record) {
+		return db.Save(record).Error
+	}
+
+	var (
+		selects, partial      = GetUpdateFields(context, record)
+		field                 = res.lockField
+		tenantSQL, tenantArgs = res.tenantCondition(context)
+	)
+
+	if field == nil && tenantSQL == "" {
+		if partial {
+			if len(selects) == 0 {
+				return nil
+			}
+			return db.Select(selects).Save(record).Error
+		}
+		return db.Save(record).Error
+	}
+
+	if partial && len(selects) == 0 && field == nil {
+		return nil
+	}
+
+	tx := db.Session(&gorm.Session{})
+	if tenantSQL != "" {
+		tx = tx.Where(tenantSQL, tenantArgs...)
+	}
+
+	if partial {
+		selects = append([]string{}, selects...)
+	} else {
+		selects = []string{"*"}
+	}
+
+	var restoreLockValue = func() {}
+	if field != nil {
+		var (
+			reflectValue      = reflect.ValueOf(record)
+			lockValue, _      = field.ValueOf(reflectValue)
+			fieldValue        = field.ReflectValueOf(reflectValue)
+			originalLockValue = reflect.New(fieldValue.Type()).Elem()
+		)
+		originalLockValue.Set(fieldValue)
+		restoreLockValue = func() { fieldValue.Set(originalLockValue) }
+
+		// updated at fields will be set by gorm, otherwise bump the version
+		if field.AutoUpdateTime == 0 {
+			switch fieldValue.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				fieldValue.SetInt(fieldValue.Int() + 1)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				fieldValue.SetUint(fieldValue.Uint() + 1)
+			default:
+				if err := field.Set(reflectValue, time.Now()); err != nil {
+					return err
+				}
+			}
+		}
+
+		if partial {
+			selects = append(selects, field.Name)
+		}
+		tx = tx.Where(fmt.Sprintf("%v = ?", db.Statement.Quote(field.DBName)), lockValue)
+	}
+
+	// explicit selects make sure the record won't be created if nothing updated
+	tx = tx.Select(selects).Save(record)
+	if tx.Error == nil && tx.RowsAffected == 0 {
+		// some databases, e.g: MySQL, report no affected rows if values are not changed, check the record exists or not,
+		// lock value is always changed, so existing records are conflicted
+		query, args := res.ToPrimaryQueryParams(res.PrimaryValueOf(record), context)
+		var count int64
+		if err := db.Session(&gorm.Session{NewDB: true}).Model(res.Value).Where(query, args...).Count(&count).Error; err != nil {
+			tx.Error = err
+		} else if count == 0 {
+			tx.Error = gorm.ErrRecordNotFound
+		} else if field != nil {
+			tx.Error = &ConflictError{Resource: res, Record: record, Field: field.Name}
+		}
+	}
+
+	if tx.Error != nil {
+		restoreLockValue()
+	}
+	return tx.Error
+}
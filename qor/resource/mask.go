@@ -0,0 +1,147 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/utils"
+)
+
+// MaskPolicy masking policy of meta, used to show sensitive values partially masked, e.g: `j***@example.com`,
+// values are masked for contexts having one of Roles, or all contexts if Roles is blank, contexts having one of UnmaskedRoles will see full values
+type MaskPolicy struct {
+	Mask          func(value string) string
+	Roles         []string
+	UnmaskedRoles []string
+}
+
+// MaskedMetaor if a meta implemented this interface, its values will be masked with the policy
+type MaskedMetaor interface {
+	GetMaskPolicy() *MaskPolicy
+}
+
+// ShouldMask check if values should be masked for context, values are always masked for nil context,
+// which is used for values stored for unknown readers, e.g: versions, audit logs
+func (policy *MaskPolicy) ShouldMask(context *qor.Context) bool {
+	if policy == nil || policy.Mask == nil {
+		return false
+	}
+
+	if context == nil {
+		return true
+	}
+
+	roles := context.Roles
+
+	if containsAnyString(policy.UnmaskedRoles, roles) {
+		return false
+	}
+	return len(policy.Roles) == 0 || containsAnyString(policy.Roles, roles)
+}
+
+// MaskValue mask value with the policy, blank values are kept as they are
+func (policy *MaskPolicy) MaskValue(value interface{}) interface{} {
+	if value = snapshotValue(value); value == nil {
+		return nil
+	}
+
+	if str := fmt.Sprint(value); str != "" {
+		return policy.Mask(str)
+	}
+	return ""
+}
+
+// MaskAll mask the whole value
+func MaskAll(value string) string {
+	return "***"
+}
+
+// MaskPartial mask value except its first `prefix` and last `suffix` characters, e.g: `***4567` for phone numbers with MaskPartial(0, 4)
+func MaskPartial(prefix, suffix int) func(value string) string {
+	return func(value string) string {
+		runes := []rune(value)
+		if len(runes) <= prefix+suffix {
+			return MaskAll(value)
+		}
+		return string(runes[:prefix]) + "***" + string(runes[len(runes)-suffix:])
+	}
+}
+
+// MaskEmail mask email's local part except its first character, e.g: `j***@example.com`
+func MaskEmail(value string) string {
+	if idx := strings.LastIndex(value, "@"); idx > 0 {
+		return MaskPartial(1, 0)(value[:idx]) + value[idx:]
+	}
+	return MaskAll(value)
+}
+
+// GetMaskPolicy get meta's masking policy
+func (meta *Meta) GetMaskPolicy() *MaskPolicy {
+	return meta.MaskPolicy
+}
+
+// SetMaskPolicy set masking policy for meta
+func (meta *Meta) SetMaskPolicy(policy *MaskPolicy) {
+	meta.MaskPolicy = policy
+}
+
+// maskPolicyOf get masking policy of meta if its values should be masked for context
+func maskPolicyOf(metaor Metaor, context *qor.Context) *MaskPolicy {
+	if masked, ok := metaor.(MaskedMetaor); ok {
+		if policy := masked.GetMaskPolicy(); policy.ShouldMask(context) {
+			return policy
+		}
+	}
+	return nil
+}
+
+// isMaskedValue check if the meta value is the masked value posted back, which shouldn't overwrite the real value
+func (processor *processor) isMaskedValue(metaValue *MetaValue) bool {
+	policy := maskPolicyOf(metaValue.Meta, processor.Context)
+	if policy == nil {
+		return false
+	}
+
+	masked := policy.MaskValue(processor.valueOf(metaValue.Meta))
+	return masked != nil && masked != "" && utils.ToString(metaValue.Value) == masked
+}
+
+// MaskChangeSet mask old and new values of changes with masking policies of resource's metas for context,
+// mask them with nil context if changes will be stored, e.g: audit logs
+func MaskChangeSet(res Resourcer, changeSet ChangeSet, context *qor.Context) ChangeSet {
+	var results ChangeSet
+	for _, change := range changeSet {
+		if metaor := metaorOfPath(res, change.Path); metaor != nil {
+			if policy := maskPolicyOf(metaor, context); policy != nil {
+				change.OldValue, change.NewValue = policy.MaskValue(change.OldValue), policy.MaskValue(change.NewValue)
+			}
+		}
+		results = append(results, change)
+	}
+	return results
+}
+
+// metaorOfPath find meta with change's path, e.g: `Addresses[1].Address1`
+func metaorOfPath(res Resourcer, path string) (metaor Metaor) {
+	metaors := res.GetMetas([]string{})
+	for _, name := range strings.Split(path, ".") {
+		if idx := strings.Index(name, "["); idx >= 0 {
+			name = name[:idx]
+		}
+
+		metaor = nil
+		for _, m := range metaors {
+			if m.GetName() == name {
+				metaor = m
+				break
+			}
+		}
+
+		if metaor == nil {
+			return nil
+		}
+		metaors = metaor.GetMetas()
+	}
+	return metaor
+}
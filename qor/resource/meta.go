@@ -63,6 +63,7 @@ type Meta struct {
 	BaseResource    Resourcer
 	Resource        Resourcer
 	Permission      *roles.Permission
+	MaskPolicy      *MaskPolicy
 }
 
 // GetBaseResource get base resource from meta
@@ -105,12 +106,22 @@ func (meta *Meta) SetValuer(fc func(interface{}, *qor.Context) interface{}) {
 	meta.Valuer = fc
 }
 
-// GetFormattedValuer get formatted valuer from meta
+// GetFormattedValuer get formatted valuer from meta, formatted values will be masked if meta has a masking policy
 func (meta *Meta) GetFormattedValuer() func(interface{}, *qor.Context) interface{} {
+	valuer := meta.Valuer
 	if meta.FormattedValuer != nil {
-		return meta.FormattedValuer
+		valuer = meta.FormattedValuer
 	}
-	return meta.Valuer
+
+	if policy := meta.MaskPolicy; policy != nil && valuer != nil {
+		return func(record interface{}, context *qor.Context) interface{} {
+			if policy.ShouldMask(context) {
+				return policy.MaskValue(valuer(record, context))
+			}
+			return valuer(record, context)
+		}
+	}
+	return valuer
 }
 
 // SetFormattedValuer set formatted valuer for meta
@@ -387,8 +398,12 @@ func setupSetter(meta *Meta, fieldName string, record interface{}) {
 					field.Set(reflect.Zero(field.Type()))
 
 					if len(primaryKeys) > 0 {
-						// replace it with new value
-						context.GetDB().Where(primaryKeys).Find(field.Addr().Interface())
+						// replace it with new value, only records of current tenant could be selected
+						db := context.GetDB()
+						if res := meta.Resource; res != nil && !reflect.ValueOf(res).IsNil() {
+							db = res.GetResource().ScopeTenant(db, context)
+						}
+						db.Where(primaryKeys).Find(field.Addr().Interface())
 					}
 
 					// Replace many 2 many relations
@@ -444,13 +459,14 @@ func setupSetter(meta *Meta, fieldName string, record interface{}) {
 			meta.Setter = commonSetter(func(field reflect.Value, metaValue *MetaValue, context *qor.Context, record interface{}) {
 				if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
 					if metaValue.Value == nil && len(metaValue.MetaValues.Values) > 0 {
-						decodeMetaValuesToField(meta.Resource, field, metaValue, context)
+						_, err := decodeMetaValuesToField(meta.Resource, record, field, metaValue, context)
+						context.AddError(err)
 						return
 					}
 
 					if scanner.Scan(metaValue.Value) != nil {
 						if err := scanner.Scan(utils.ToString(metaValue.Value)); err != nil {
-							context.AddError(err)
+							context.AddError(validations.NewError(record, meta.Name, err.Error()))
 							return
 						}
 					}
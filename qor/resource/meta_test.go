@@ -458,6 +458,63 @@ func TestMeta_PreInitialize(t *testing.T) {
 	t.Log(f.Schema.Relationships.Relations[f.Name].FieldSchema.Table)
 }
 
+func TestMeta_MaskPolicy(t *testing.T) {
+	type Customer struct {
+		gorm.Model
+		Email string
+		Phone string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&Customer{})
+	db.AutoMigrate(&Customer{})
+
+	var (
+		support  = &qor.Context{Config: &qor.Config{DB: db}, Roles: []string{"support"}}
+		admin    = &qor.Context{Config: &qor.Config{DB: db}, Roles: []string{"admin"}}
+		res      = resource.New(&Customer{})
+		customer = Customer{Email: "jinzhu@example.com", Phone: "555-123-4567"}
+		email    = &resource.Meta{Name: "Email", BaseResource: res, MaskPolicy: &resource.MaskPolicy{Mask: resource.MaskEmail, UnmaskedRoles: []string{"admin"}}}
+		phone    = &resource.Meta{Name: "Phone", BaseResource: res}
+	)
+
+	for _, meta := range []*resource.Meta{email, phone} {
+		meta.PreInitialize()
+		meta.Initialize()
+	}
+	phone.SetMaskPolicy(&resource.MaskPolicy{Mask: resource.MaskPartial(0, 4), Roles: []string{"support"}})
+	db.Create(&customer)
+
+	if value := email.GetFormattedValuer()(&customer, support); value != "j***@example.com" {
+		t.Errorf("email should be masked, but got %v", value)
+	}
+
+	if value := phone.GetFormattedValuer()(&customer, support); value != "***4567" {
+		t.Errorf("phone should be masked, but got %v", value)
+	}
+
+	if value := email.GetFormattedValuer()(&customer, admin); value != "jinzhu@example.com" {
+		t.Errorf("email should not be masked for unmasked roles, but got %v", value)
+	}
+
+	if value := phone.GetFormattedValuer()(&customer, admin); value != "555-123-4567" {
+		t.Errorf("phone should only be masked for its roles, but got %v", value)
+	}
+
+	// masked values posted back
+	metaValues := &resource.MetaValues{Values: []*resource.MetaValue{
+		{Name: "Email", Meta: testMetaor{email}, Value: "j***@example.com"},
+		{Name: "Phone", Meta: testMetaor{phone}, Value: "555-000-0000"},
+	}}
+	if err := resource.DecodeToResource(res, &customer, metaValues, support).Start(); err != nil {
+		t.Fatalf("no error should happen when decode customer, but got %v", err)
+	}
+
+	if customer.Email != "jinzhu@example.com" || customer.Phone != "555-000-0000" {
+		t.Errorf("masked value posted back should not overwrite the real value, but got %v, %v", customer.Email, customer.Phone)
+	}
+}
+
 func TestMeta_ValuerForDirectField(t *testing.T) {
 	var (
 		user = &User{Age: 18}
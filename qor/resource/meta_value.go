@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/saitofun/qor/qor"
@@ -32,13 +33,22 @@ type MetaValue struct {
 	MetaValues *MetaValues
 }
 
-func decodeMetaValuesToField(res Resourcer, field reflect.Value, metaValue *MetaValue, context *qor.Context) {
+// decodeMetaValuesToField decode nested meta values into field of record, return changes of nested record prefixed with the nested meta's path,
+// nested records are tracked with the record, so their cache will be invalidated when the record is saved
+func decodeMetaValuesToField(res Resourcer, record interface{}, field reflect.Value, metaValue *MetaValue, context *qor.Context) (ChangeSet, error) {
 	if field.Kind() == reflect.Struct {
 		value := reflect.New(field.Type())
 		associationProcessor := DecodeToResource(res, value.Interface(), metaValue.MetaValues, context)
-		associationProcessor.Start()
+		err := associationProcessor.Start()
+		// changes of nested record are included in the record's change set
+		clearChangeSet(context, value.Interface())
+		addNestedRecord(context, record, res, value.Interface())
+		if err != nil {
+			return nil, nestedErrors(metaValue.Name, err)
+		}
 		if !associationProcessor.SkipLeft {
 			field.Set(value.Elem())
+			return associationProcessor.ChangeSet.prefix(metaValue.Name), nil
 		}
 	} else if field.Kind() == reflect.Slice {
 		if metaValue.Index == 0 {
@@ -52,9 +62,16 @@ func decodeMetaValuesToField(res Resourcer, field reflect.Value, metaValue *Meta
 			isPtr = true
 		}
 
+		path := fmt.Sprintf("%v[%v]", metaValue.Name, metaValue.Index)
 		value := reflect.New(fieldType)
 		associationProcessor := DecodeToResource(res, value.Interface(), metaValue.MetaValues, context)
-		associationProcessor.Start()
+		err := associationProcessor.Start()
+		// changes of nested record are included in the record's change set
+		clearChangeSet(context, value.Interface())
+		addNestedRecord(context, record, res, value.Interface())
+		if err != nil {
+			return nil, nestedErrors(path, err)
+		}
 		if !associationProcessor.SkipLeft {
 			if !reflect.DeepEqual(reflect.Zero(fieldType).Interface(), value.Elem().Interface()) {
 				if isPtr {
@@ -62,7 +79,26 @@ func decodeMetaValuesToField(res Resourcer, field reflect.Value, metaValue *Meta
 				} else {
 					field.Set(reflect.Append(field, value.Elem()))
 				}
+				return associationProcessor.ChangeSet.prefix(path), nil
 			}
 		}
 	}
+	return nil, nil
+}
+
+// nestedErrors address errors of nested processor to the nested meta's path, skip left errors will be ignored
+func nestedErrors(path string, err error) error {
+	var errs, results qor.Errors
+	errs.AddError(err)
+
+	for _, e := range errs.GetErrors() {
+		if e != ErrProcessorSkipLeft {
+			results.AddError(e)
+		}
+	}
+
+	if !results.HasError() {
+		return nil
+	}
+	return qor.PrefixErrorPath(path, results)
 }
@@ -0,0 +1,497 @@
+package resource
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+const (
+	// MergePatchMediaType media type of JSON Merge Patch (RFC 7396)
+	MergePatchMediaType = "application/merge-patch+json"
+	// JSONPatchMediaType media type of JSON Patch (RFC 6902)
+	JSONPatchMediaType = "application/json-patch+json"
+)
+
+// ErrInvalidPatch patch couldn't be applied to the record
+var ErrInvalidPatch = errors.New("invalid patch")
+
+func init() {
+	RegisterDecoder(MergePatchMediaType, func(context *qor.Context, result interface{}, metaors []Metaor) (*MetaValues, error) {
+		defer context.Request.Body.Close()
+		return ConvertMergePatchToMetaValues(context.Request.Body, result, metaors, context)
+	})
+
+	RegisterDecoder(JSONPatchMediaType, func(context *qor.Context, result interface{}, metaors []Metaor) (*MetaValues, error) {
+		defer context.Request.Body.Close()
+		return ConvertJSONPatchToMetaValues(context.Request.Body, result, metaors, context)
+	})
+}
+
+// ConvertMergePatchToMetaValues convert JSON Merge Patch (RFC 7396) to meta values, the patch is merged with record's current values,
+// only patched metas will be included, `null` will be converted to a nil value
+func ConvertMergePatchToMetaValues(reader io.Reader, record interface{}, metaors []Metaor, context *qor.Context) (*MetaValues, error) {
+	var patch map[string]interface{}
+	if err := json.NewDecoder(reader).Decode(&patch); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	var names []string
+	for name := range patch {
+		names = append(names, name)
+	}
+
+	document, err := patchDocumentOf(record, names, metaors, context)
+	if err != nil {
+		return nil, err
+	}
+	return patchedMetaValues(mergePatch(document, patch).(map[string]interface{}), names, metaors)
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+
+	for key, value := range patchMap {
+		if value == nil {
+			delete(targetMap, key)
+		} else {
+			targetMap[key] = mergePatch(targetMap[key], value)
+		}
+	}
+	return targetMap
+}
+
+type jsonPatchOperation struct {
+	Op    string           `json:"op"`
+	Path  string           `json:"path"`
+	From  string           `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// ConvertJSONPatchToMetaValues convert JSON Patch (RFC 6902) to meta values, operations are applied to record's current values,
+// only patched metas will be included, removed metas will be converted to a nil value
+func ConvertJSONPatchToMetaValues(reader io.Reader, record interface{}, metaors []Metaor, context *qor.Context) (*MetaValues, error) {
+	var operations []jsonPatchOperation
+	if err := json.NewDecoder(reader).Decode(&operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	var (
+		names   []string
+		touched = map[string]bool{}
+	)
+
+	for idx, operation := range operations {
+		for _, path := range []string{operation.Path, operation.From} {
+			if tokens, err := parseJSONPointer(path); err == nil && len(tokens) > 0 {
+				// operations like `test`, `copy` could reveal values, so metas couldn't be read are not patchable
+				if !isReadableMeta(tokens[0], metaors, context) {
+					return nil, fmt.Errorf("%w: operation %v (%v %v), %v is not readable", ErrInvalidPatch, idx, operation.Op, operation.Path, tokens[0])
+				}
+
+				if !touched[tokens[0]] {
+					touched[tokens[0]] = true
+					names = append(names, tokens[0])
+				}
+			}
+		}
+	}
+
+	document, err := patchDocumentOf(record, names, metaors, context)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{} = document
+	for idx, operation := range operations {
+		if result, err = applyJSONPatchOperation(result, operation); err != nil {
+			return nil, fmt.Errorf("%w: operation %v (%v %v), %v", ErrInvalidPatch, idx, operation.Op, operation.Path, err)
+		}
+	}
+	return patchedMetaValues(result.(map[string]interface{}), names, metaors)
+}
+
+func applyJSONPatchOperation(document interface{}, operation jsonPatchOperation) (interface{}, error) {
+	path, err := parseJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return nil, errors.New("patching the whole record is not supported")
+	}
+
+	var value interface{}
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, errors.New("missing value")
+		}
+
+		if err := json.Unmarshal(*operation.Value, &value); err != nil {
+			return nil, err
+		}
+	case "move", "copy":
+		from, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+
+		if value, err = getJSONPointerValue(document, from); err != nil {
+			return nil, err
+		}
+
+		if operation.Op == "move" {
+			if strings.HasPrefix(operation.Path+"/", operation.From+"/") && operation.Path != operation.From {
+				return nil, errors.New("can't move a value into its children")
+			}
+
+			if document, err = removeJSONPointerValue(document, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = copyJSONValue(value)
+		}
+	}
+
+	switch operation.Op {
+	case "add", "move", "copy":
+		return addJSONPointerValue(document, path, value)
+	case "remove":
+		return removeJSONPointerValue(document, path)
+	case "replace":
+		if document, err = removeJSONPointerValue(document, path); err != nil {
+			return nil, err
+		}
+		return addJSONPointerValue(document, path, value)
+	case "test":
+		current, err := getJSONPointerValue(document, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(current, value) {
+			return nil, errors.New("test failed")
+		}
+		return document, nil
+	}
+	return nil, fmt.Errorf("unknown operation %v", operation.Op)
+}
+
+// parseJSONPointer parse JSON Pointer (RFC 6901), e.g: `/Addresses/0/City`
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid path %v", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for idx, token := range tokens {
+		tokens[idx] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+func jsonArrayIndex(token string, length int) (int, error) {
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || idx >= length || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid index %v", token)
+	}
+	return idx, nil
+}
+
+func getJSONPointerValue(document interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		switch node := document.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%v not found", token)
+			}
+			document = value
+		case []interface{}:
+			idx, err := jsonArrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			document = node[idx]
+		default:
+			return nil, fmt.Errorf("%v not found", token)
+		}
+	}
+	return document, nil
+}
+
+func addJSONPointerValue(document interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	token := tokens[0]
+	switch node := document.(type) {
+	case map[string]interface{}:
+		if len(tokens) == 1 {
+			node[token] = value
+			return node, nil
+		}
+
+		child, ok := node[token]
+		if !ok {
+			return nil, fmt.Errorf("%v not found", token)
+		}
+
+		result, err := addJSONPointerValue(child, tokens[1:], value)
+		node[token] = result
+		return node, err
+	case []interface{}:
+		if len(tokens) == 1 {
+			idx := len(node)
+			if token != "-" {
+				var err error
+				if idx, err = jsonArrayIndex(token, len(node)+1); err != nil {
+					return nil, err
+				}
+			}
+
+			node = append(node, nil)
+			copy(node[idx+1:], node[idx:])
+			node[idx] = value
+			return node, nil
+		}
+
+		idx, err := jsonArrayIndex(token, len(node))
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := addJSONPointerValue(node[idx], tokens[1:], value)
+		node[idx] = result
+		return node, err
+	}
+	return nil, fmt.Errorf("%v not found", token)
+}
+
+func removeJSONPointerValue(document interface{}, tokens []string) (interface{}, error) {
+	token := tokens[0]
+	switch node := document.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, fmt.Errorf("%v not found", token)
+		}
+
+		if len(tokens) == 1 {
+			delete(node, token)
+			return node, nil
+		}
+
+		result, err := removeJSONPointerValue(child, tokens[1:])
+		node[token] = result
+		return node, err
+	case []interface{}:
+		idx, err := jsonArrayIndex(token, len(node))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tokens) == 1 {
+			return append(node[:idx], node[idx+1:]...), nil
+		}
+
+		result, err := removeJSONPointerValue(node[idx], tokens[1:])
+		node[idx] = result
+		return node, err
+	}
+	return nil, fmt.Errorf("%v not found", token)
+}
+
+func copyJSONValue(value interface{}) interface{} {
+	var result interface{}
+	if bytes, err := json.Marshal(value); err == nil {
+		json.Unmarshal(bytes, &result)
+	}
+	return result
+}
+
+// isReadableMeta check the meta with name could be read with context or not, unknown metas are readable, they will be ignored
+func isReadableMeta(name string, metaors []Metaor, context *qor.Context) bool {
+	for _, metaor := range metaors {
+		if metaor.GetName() == name {
+			return metaor.HasPermission(roles.Read, context)
+		}
+	}
+	return true
+}
+
+// patchDocumentOf get current values of metas with names from record as a JSON document, metas couldn't be read are skipped,
+// values of masked metas are masked
+func patchDocumentOf(record interface{}, names []string, metaors []Metaor, context *qor.Context) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	for _, name := range names {
+		for _, metaor := range metaors {
+			if metaor.GetName() == name {
+				if !metaor.HasPermission(roles.Read, context) {
+					break
+				}
+
+				if valuer := metaor.GetValuer(); valuer != nil && record != nil {
+					value := valuer(record, context)
+					// operations like `test`, `copy` couldn't reveal masked values
+					if policy := maskPolicyOf(metaor, context); policy != nil {
+						value = policy.MaskValue(value)
+					}
+
+					value = copyJSONValue(value)
+					if value != nil {
+						document[name] = value
+					}
+				}
+				break
+			}
+		}
+	}
+	return document, nil
+}
+
+// patchedMetaValues convert patched metas to meta values, metas removed from the document will get a nil value
+func patchedMetaValues(document map[string]interface{}, names []string, metaors []Metaor) (*MetaValues, error) {
+	values := map[string]interface{}{}
+	for _, name := range names {
+		if value, ok := document[name]; ok {
+			values[name] = value
+		}
+	}
+
+	metaValues, err := convertMapToMetaValues(values, metaors)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if _, ok := values[name]; !ok {
+			metaValue := &MetaValue{Name: name}
+			for _, metaor := range metaors {
+				if metaor.GetName() == name {
+					metaValue.Meta = metaor
+				}
+			}
+			metaValues.Values = append(metaValues.Values, metaValue)
+		}
+	}
+	return metaValues, nil
+}
+
+type updateFieldsKey struct{}
+
+// SetUpdateFields set fields to update of the record, when the record is saved with the context, only given fields will be updated,
+// used to save partial updates, the fields will be cleared after the record saved
+func SetUpdateFields(context *qor.Context, record interface{}, fields []string) {
+	if context == nil || record == nil || !reflect.TypeOf(record).Comparable() {
+		return
+	}
+
+	updateFields, ok := context.GetContext().Value(updateFieldsKey{}).(map[interface{}][]string)
+	if !ok {
+		updateFields = map[interface{}][]string{}
+		context.SetContext(stdcontext.WithValue(context.GetContext(), updateFieldsKey{}, updateFields))
+	}
+	updateFields[record] = fields
+}
+
+// GetUpdateFields get fields to update of the record, return false if all fields should be updated
+func GetUpdateFields(context *qor.Context, record interface{}) ([]string, bool) {
+	if context == nil || record == nil || !reflect.TypeOf(record).Comparable() {
+		return nil, false
+	}
+
+	if updateFields, ok := context.GetContext().Value(updateFieldsKey{}).(map[interface{}][]string); ok {
+		fields, ok := updateFields[record]
+		return fields, ok
+	}
+	return nil, false
+}
+
+func clearUpdateFields(context *qor.Context, record interface{}) {
+	if context == nil || record == nil || !reflect.TypeOf(record).Comparable() {
+		return
+	}
+
+	if updateFields, ok := context.GetContext().Value(updateFieldsKey{}).(map[interface{}][]string); ok {
+		delete(updateFields, record)
+	}
+}
+
+// snapshotFields get values of record's columns, pointers are dereferenced, so changes of pointed values could be detected
+func snapshotFields(record interface{}) map[string]interface{} {
+	var (
+		snapshot     = map[string]interface{}{}
+		schema, _    = gorm.Parse(record)
+		reflectValue = reflect.ValueOf(record)
+	)
+
+	if schema != nil {
+		for _, field := range schema.Fields {
+			if field.DBName != "" && !field.PrimaryKey {
+				value, _ := field.ValueOf(reflectValue)
+				v := reflect.ValueOf(value)
+				for v.Kind() == reflect.Ptr && !v.IsNil() {
+					v = v.Elem()
+				}
+
+				if v.IsValid() && v.Kind() != reflect.Ptr {
+					snapshot[field.Name] = v.Interface()
+				} else {
+					snapshot[field.Name] = nil
+				}
+			}
+		}
+	}
+	return snapshot
+}
+
+// changedFields compare record with its snapshot, return changed fields and patched associations
+func changedFields(snapshot map[string]interface{}, record interface{}, metaValues *MetaValues) (fields []string) {
+	schema, _ := gorm.Parse(record)
+	if schema == nil {
+		return nil
+	}
+
+	for name, value := range snapshotFields(record) {
+		if !reflect.DeepEqual(snapshot[name], value) {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+
+	if metaValues != nil {
+		for _, metaValue := range metaValues.Values {
+			if metaValue.Meta != nil {
+				if _, ok := schema.Relationships.Relations[metaValue.Meta.GetFieldName()]; ok {
+					fields = append(fields, metaValue.Meta.GetFieldName())
+				}
+			}
+		}
+	}
+	return fields
+}
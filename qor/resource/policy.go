@@ -0,0 +1,136 @@
+package resource
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+// RecordPolicy record-level permission policy, e.g: editors could only update articles they authored,
+// Check decide if context could access the record, it is checked when finding one, saving and deleting records,
+// Scope translate the policy into db conditions, so only permitted records will be listed when finding many records,
+// the policy is only applied to contexts having one of its Roles, or all contexts if Roles is blank
+type RecordPolicy struct {
+	Name  string
+	Roles []string
+	Check func(record interface{}, context *qor.Context) bool
+	Scope func(db *gorm.DB, context *qor.Context) *gorm.DB
+}
+
+// AddRecordPolicy add record-level permission policy for mode, use roles.CRUD to add it for all modes,
+// records need to pass all applied policies, policy with same name will be replaced
+func (res *Resource) AddRecordPolicy(mode roles.PermissionMode, policy *RecordPolicy) {
+	if mode == roles.CRUD {
+		for _, mode := range []roles.PermissionMode{roles.Create, roles.Read, roles.Update, roles.Delete} {
+			res.AddRecordPolicy(mode, policy)
+		}
+		return
+	}
+
+	if res.recordPolicies == nil {
+		res.recordPolicies = map[roles.PermissionMode][]*RecordPolicy{}
+	}
+
+	for idx, p := range res.recordPolicies[mode] {
+		if p.Name == policy.Name {
+			res.recordPolicies[mode][idx] = policy
+			return
+		}
+	}
+	res.recordPolicies[mode] = append(res.recordPolicies[mode], policy)
+}
+
+// GetRecordPolicies get record-level permission policies of mode
+func (res *Resource) GetRecordPolicies(mode roles.PermissionMode) []*RecordPolicy {
+	return res.recordPolicies[mode]
+}
+
+// HasRecordPermission check if context has permission of mode for the record, both resource's permission and record policies are checked
+func (res *Resource) HasRecordPermission(mode roles.PermissionMode, record interface{}, context *qor.Context) bool {
+	return res.HasPermission(mode, context) && res.checkRecordPolicies(mode, record, context) == nil
+}
+
+// ScopeRecordPolicies scope db with record policies of mode, policies without Scope won't restrict listed records
+func (res *Resource) ScopeRecordPolicies(mode roles.PermissionMode, db *gorm.DB, context *qor.Context) *gorm.DB {
+	for _, policy := range res.appliedRecordPolicies(mode, context) {
+		if policy.Scope != nil {
+			db = policy.Scope(db, context)
+		}
+	}
+	return db
+}
+
+func (res *Resource) appliedRecordPolicies(mode roles.PermissionMode, context *qor.Context) (policies []*RecordPolicy) {
+	for _, policy := range res.recordPolicies[mode] {
+		if len(policy.Roles) == 0 || (context != nil && containsAnyString(policy.Roles, context.Roles)) {
+			policies = append(policies, policy)
+		}
+	}
+	return
+}
+
+// checkRecordPolicies check records with record policies of mode, return roles.ErrPermissionDenied if any of them is not permitted
+func (res *Resource) checkRecordPolicies(mode roles.PermissionMode, record interface{}, context *qor.Context) error {
+	for _, policy := range res.appliedRecordPolicies(mode, context) {
+		if policy.Check != nil && !policy.Check(record, context) {
+			return roles.ErrPermissionDenied
+		}
+	}
+	return nil
+}
+
+// checkSavingRecordPolicies check record that will be saved, updating records are also checked with their stored values,
+// so records not permitted couldn't be changed to be permitted
+func (res *Resource) checkSavingRecordPolicies(record interface{}, context *qor.Context) error {
+	if res.isNewRecord(record) {
+		return res.checkRecordPolicies(roles.Create, record, context)
+	}
+
+	if err := res.checkStoredRecordPolicies(roles.Update, record, context); err != nil {
+		return err
+	}
+	return res.checkRecordPolicies(roles.Update, record, context)
+}
+
+// checkStoredRecordPolicies check record's stored values with record policies of mode, records not found are skipped
+func (res *Resource) checkStoredRecordPolicies(mode roles.PermissionMode, record interface{}, context *qor.Context) error {
+	var hasCheck bool
+	for _, policy := range res.appliedRecordPolicies(mode, context) {
+		if policy.Check != nil {
+			hasCheck = true
+		}
+	}
+
+	if !hasCheck {
+		return nil
+	}
+
+	stored := res.NewStruct()
+	for _, field := range res.PrimaryFields {
+		if value, zero := field.ValueOf(reflect.ValueOf(record)); !zero {
+			if err := field.Set(reflect.ValueOf(stored), value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := res.ScopeTenant(context.GetDB().Session(&gorm.Session{}), context).First(stored).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return res.checkRecordPolicies(mode, stored, context)
+}
+
+func containsAnyString(values []string, targets []string) bool {
+	for _, target := range targets {
+		if containsString(values, target) {
+			return true
+		}
+	}
+	return false
+}
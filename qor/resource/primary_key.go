@@ -0,0 +1,43 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PrimaryValueSeparator separator of multiple primary values, e.g: `1,en-US`
+const PrimaryValueSeparator = ","
+
+var (
+	primaryValueEncoder = strings.NewReplacer("~", "~7E", PrimaryValueSeparator, "~2C", "/", "~2F")
+	primaryValueDecoder = strings.NewReplacer("~2C", PrimaryValueSeparator, "~2F", "/", "~7E", "~")
+)
+
+// EncodePrimaryValues encode primary values into one reversible primary value, separators and slashes in values are escaped,
+// e.g: `a,b/c` => `a~2Cb~2Fc`, so they could be decoded back with DecodePrimaryValues and used as a path segment of URLs
+func EncodePrimaryValues(values ...string) string {
+	var escaped []string
+	for _, value := range values {
+		escaped = append(escaped, primaryValueEncoder.Replace(value))
+	}
+	return strings.Join(escaped, PrimaryValueSeparator)
+}
+
+// DecodePrimaryValues decode primary value encoded with EncodePrimaryValues
+func DecodePrimaryValues(value string) (values []string) {
+	for _, escaped := range strings.Split(value, PrimaryValueSeparator) {
+		values = append(values, primaryValueDecoder.Replace(escaped))
+	}
+	return
+}
+
+// PrimaryValueOf get encoded primary value of record, which could be used as context's ResourceID and in URLs
+func (res *Resource) PrimaryValueOf(record interface{}) string {
+	var values []string
+	for _, field := range res.PrimaryFields {
+		v, _ := field.ValueOf(reflect.ValueOf(record))
+		values = append(values, fmt.Sprint(v))
+	}
+	return EncodePrimaryValues(values...)
+}
@@ -20,6 +20,10 @@ type processor struct {
 	Context    *qor.Context
 	MetaValues *MetaValues
 	SkipLeft   bool
+	ChangeSet  ChangeSet
+
+	snapshot        map[string]interface{}
+	nestedChangeSet ChangeSet
 }
 
 // DecodeToResource decode meta values to resource result
@@ -45,6 +49,11 @@ func (processor *processor) checkSkipLeft(errs ...error) bool {
 func (processor *processor) Initialize() error {
 	err := processor.Resource.CallFindOne(processor.Result, processor.MetaValues, processor.Context)
 	processor.checkSkipLeft(err)
+
+	// take a snapshot of existing records to compute their changes after committed
+	if !processor.SkipLeft && !processor.isNewRecord() {
+		processor.snapshot = processor.snapshotMetas()
+	}
 	return err
 }
 
@@ -74,13 +83,10 @@ func (processor *processor) decode() (errs []error) {
 		return
 	}
 
-	newRecord := true
-	// scope := &gorm.Scope{Value: processor.Result}
-	scope, _ := gorm.Parse(processor.Result)
-	if primaryField := scope.PrioritizedPrimaryField; primaryField != nil {
-		if _, zero := primaryField.ValueOf(reflect.ValueOf(processor.Result)); !zero {
-			newRecord = false
-		} else {
+	newRecord := processor.isNewRecord()
+	if newRecord {
+		if scope, _ := gorm.Parse(processor.Result); scope != nil && scope.PrioritizedPrimaryField != nil {
+			primaryField := scope.PrioritizedPrimaryField
 			for _, metaValue := range processor.MetaValues.Values {
 				if metaValue.Meta != nil && metaValue.Meta.GetFieldName() == primaryField.Name {
 					if v := utils.ToString(metaValue.Value); v != "" && v != "0" {
@@ -101,10 +107,13 @@ func (processor *processor) decode() (errs []error) {
 			continue
 		} else if !newRecord && !meta.HasPermission(roles.Update, processor.Context) {
 			continue
+		} else if !newRecord && processor.isMaskedValue(metaValue) {
+			// masked value posted back, keep the real value
+			continue
 		}
 
 		if setter := meta.GetSetter(); setter != nil {
-			setter(processor.Result, metaValue, processor.Context)
+			errs = append(errs, processor.callSetter(setter, metaValue)...)
 		}
 
 		if metaValue.MetaValues != nil && len(metaValue.MetaValues.Values) > 0 {
@@ -113,7 +122,11 @@ func (processor *processor) decode() (errs []error) {
 				// Only decode nested meta value into struct if no Setter defined
 				if meta.GetSetter() == nil || reflect.Indirect(field).Type() == utils.ModelType(res.NewStruct()) {
 					if _, ok := field.Addr().Interface().(sql.Scanner); !ok {
-						decodeMetaValuesToField(res, field, metaValue, processor.Context)
+						changeSet, err := decodeMetaValuesToField(res, processor.Result, field, metaValue, processor.Context)
+						if err != nil {
+							errs = append(errs, err)
+						}
+						processor.nestedChangeSet = append(processor.nestedChangeSet, changeSet...)
 					}
 				}
 			}
@@ -123,6 +136,21 @@ func (processor *processor) decode() (errs []error) {
 	return
 }
 
+// callSetter call meta's setter, errors added to context by the setter are returned, so they could be addressed with path of nested metas
+func (processor *processor) callSetter(setter func(interface{}, *MetaValue, *qor.Context), metaValue *MetaValue) []error {
+	existing := processor.Context.GetErrors()
+	count := len(existing)
+	setter(processor.Result, metaValue, processor.Context)
+
+	if errs := processor.Context.GetErrors(); len(errs) > count {
+		added := append([]error{}, errs[count:]...)
+		processor.Context.Errors = qor.Errors{}
+		processor.Context.AddError(existing[:count]...)
+		return added
+	}
+	return nil
+}
+
 // Start start processor
 func (processor *processor) Start() error {
 	var errs qor.Errors
@@ -144,6 +172,8 @@ func (processor *processor) Commit() error {
 		return nil
 	}
 
+	// processors could get changes with GetChangeSet, changes made by them will be included after they are run
+	processor.setChangeSet()
 	for _, p := range processor.Resource.GetResource().Processors {
 		if err := p.Handler(processor.Result, processor.MetaValues, processor.Context); err != nil {
 			if processor.checkSkipLeft(err) {
@@ -152,5 +182,20 @@ func (processor *processor) Commit() error {
 			errs.AddError(err)
 		}
 	}
+	processor.setChangeSet()
 	return errs
 }
+
+func (processor *processor) setChangeSet() {
+	processor.ChangeSet = processor.computeChangeSet()
+	SetChangeSet(processor.Context, processor.Result, processor.ChangeSet)
+}
+
+func (processor *processor) isNewRecord() bool {
+	scope, _ := gorm.Parse(processor.Result)
+	if scope == nil || scope.PrioritizedPrimaryField == nil {
+		return true
+	}
+	_, zero := scope.PrioritizedPrimaryField.ValueOf(reflect.ValueOf(processor.Result))
+	return zero
+}
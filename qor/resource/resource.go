@@ -18,6 +18,8 @@ type Resourcer interface {
 	CallFindOne(interface{}, *MetaValues, *qor.Context) error
 	CallSave(interface{}, *qor.Context) error
 	CallDelete(interface{}, *qor.Context) error
+	CallSaveMany(interface{}, *qor.Context) error
+	CallDeleteMany(interface{}, *qor.Context) error
 	NewSlice() interface{}
 	NewStruct() interface{}
 }
@@ -39,17 +41,30 @@ type ConfigureResourceInterface interface {
 
 // Resource is a struct that including basic definition of qor resource
 type Resource struct {
-	Name            string
-	Value           interface{}
-	FindOneHandler  HandlerWithMetas
-	FindManyHandler Handler
-	SaveHandler     Handler
-	DeleteHandler   Handler
-	Permission      *roles.Permission
-	Validators      []*Validator
-	Processors      []*Processor
-	PrimaryFields   []*gorm.Field
-	primaryField    *gorm.Field
+	Name              string
+	Value             interface{}
+	FindOneHandler    HandlerWithMetas
+	FindManyHandler   Handler
+	SaveHandler       Handler
+	DeleteHandler     Handler
+	SaveManyHandler   Handler // records are saved in one transaction, none of them will be saved if any of them failed
+	DeleteManyHandler Handler // records are deleted in one transaction, none of them will be deleted if any of them failed
+	RestoreHandler    Handler
+	PurgeHandler      Handler
+	Permission        *roles.Permission
+	DB                *gorm.DB
+	DBResolver        qor.DBResolver
+	// BatchSize records count of each insert statement when saving records in batch with CallSaveMany
+	BatchSize      int
+	Validators     []*Validator
+	Processors     []*Processor
+	PrimaryFields  []*gorm.Field
+	primaryField   *gorm.Field
+	lockField      *gorm.Field
+	tenantField    *gorm.Field
+	recordPolicies map[roles.PermissionMode][]*RecordPolicy
+	hooks          map[HookKind][]*Hook
+	cache          Cache
 }
 
 // New initialize qor resource
@@ -59,13 +74,17 @@ func New(value interface{}) *Resource {
 	}
 	var (
 		name = utils.HumanizeString(utils.ModelType(value).Name())
-		res  = &Resource{Value: value, Name: name}
+		res  = &Resource{Value: value, Name: name, BatchSize: DefaultBatchSize}
 	)
 
 	res.FindOneHandler = res.findOneHandler
 	res.FindManyHandler = res.findManyHandler
 	res.SaveHandler = res.saveHandler
 	res.DeleteHandler = res.deleteHandler
+	res.SaveManyHandler = res.saveManyHandler
+	res.DeleteManyHandler = res.deleteManyHandler
+	res.RestoreHandler = res.restoreHandler
+	res.PurgeHandler = res.purgeHandler
 	res.SetPrimaryFields()
 	return res
 }
@@ -111,6 +130,8 @@ func (res *Resource) SetPrimaryFields(fields ...string) error {
 type Validator struct {
 	Name    string
 	Handler HandlerWithMetas
+	// Required names of metas required by the validator, they will be marked as required in JSON schema
+	Required []string
 }
 
 // AddValidator add validator to resource, it will invoked when creating, updating, and will rollback the change if validator return any error
@@ -167,10 +188,10 @@ func (res *Resource) GetMetas([]string) []Metaor {
 	panic("not defined")
 }
 
-// HasPermission check permission of resource
+// HasPermission check permission of resource, modes like Restore, Purge are denied unless they are allowed explicitly
 func (res *Resource) HasPermission(mode roles.PermissionMode, context *qor.Context) bool {
 	if res == nil || res.Permission == nil {
-		return true
+		return !roles.IsExplicitMode(mode)
 	}
 
 	var roles = []interface{}{}
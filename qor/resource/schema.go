@@ -1,10 +1,17 @@
 package resource
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/saitofun/qor/qor"
@@ -69,6 +76,166 @@ func ConvertJSONToMetaValues(reader io.Reader, metaors []Metaor) (*MetaValues, e
 	return nil, err
 }
 
+// ConvertCSVToMetaValues convert csv to meta values, first row is the header, each following row will be converted to a meta values
+// headers are matched with metas' names, nested metas could be addressed like `Address.City`, collections like `Items[0].SKU`
+func ConvertCSVToMetaValues(reader io.Reader, metaors []Metaor) ([]*MetaValues, error) {
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return ConvertRowsToMetaValues(rows, metaors)
+}
+
+var headerSegmentRegexp = regexp.MustCompile(`^\s*([^.\[\]]+?)\s*(?:\[(\d+)\])?\s*$`)
+
+type headerSegment struct {
+	Name    string
+	Index   int
+	Indexed bool
+}
+
+func parseHeader(header string, metaors []Metaor) ([]headerSegment, error) {
+	var segments []headerSegment
+
+	for _, part := range strings.Split(header, ".") {
+		matches := headerSegmentRegexp.FindStringSubmatch(part)
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("invalid header %v", header)
+		}
+
+		segment := headerSegment{Name: matches[1]}
+		if matches[2] != "" {
+			segment.Index, _ = strconv.Atoi(matches[2])
+			segment.Indexed = true
+		}
+
+		// match header with meta's name or field name, case insensitive
+		var metaor Metaor
+		for _, m := range metaors {
+			if m.GetName() == segment.Name {
+				metaor = m
+				break
+			}
+
+			if strings.EqualFold(m.GetName(), segment.Name) || strings.EqualFold(m.GetFieldName(), segment.Name) {
+				metaor = m
+			}
+		}
+
+		metaors = nil
+		if metaor != nil {
+			segment.Name = metaor.GetName()
+			metaors = metaor.GetMetas()
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// ConvertRowsToMetaValues convert rows to meta values, first row is the header, blank rows will be skipped
+func ConvertRowsToMetaValues(rows [][]string, metaors []Metaor) ([]*MetaValues, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var headers [][]headerSegment
+	for _, header := range rows[0] {
+		segments, err := parseHeader(header, metaors)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, segments)
+	}
+
+	var results []*MetaValues
+	for _, row := range rows[1:] {
+		values := map[string]interface{}{}
+		for idx, cell := range row {
+			if idx < len(headers) && len(headers[idx]) > 0 {
+				setRowValue(values, headers[idx], cell)
+			}
+		}
+
+		if value, blank := normalizeRowValue(values); !blank {
+			metaValues, err := convertMapToMetaValues(value.(map[string]interface{}), metaors)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, metaValues)
+		}
+	}
+	return results, nil
+}
+
+// setRowValue set cell's value to nested maps, collections are kept in maps with index as key, until normalized
+func setRowValue(values map[string]interface{}, segments []headerSegment, value string) {
+	segment := segments[0]
+	if len(segments) == 1 && !segment.Indexed {
+		values[segment.Name] = value
+		return
+	}
+
+	var child map[string]interface{}
+	if segment.Indexed {
+		collection, ok := values[segment.Name].(map[int]map[string]interface{})
+		if !ok {
+			collection = map[int]map[string]interface{}{}
+			values[segment.Name] = collection
+		}
+
+		if child, ok = collection[segment.Index]; !ok {
+			child = map[string]interface{}{}
+			collection[segment.Index] = child
+		}
+	} else {
+		var ok bool
+		if child, ok = values[segment.Name].(map[string]interface{}); !ok {
+			child = map[string]interface{}{}
+			values[segment.Name] = child
+		}
+	}
+
+	if len(segments) > 1 {
+		setRowValue(child, segments[1:], value)
+	}
+}
+
+// normalizeRowValue convert collections to slices ordered by index, blank nested values will be removed
+func normalizeRowValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, strings.TrimSpace(v) == ""
+	case map[string]interface{}:
+		blank := true
+		for key, child := range v {
+			if result, isBlank := normalizeRowValue(child); isBlank {
+				if reflect.TypeOf(child).Kind() == reflect.Map {
+					delete(v, key)
+				}
+			} else {
+				v[key] = result
+				blank = false
+			}
+		}
+		return v, blank
+	case map[int]map[string]interface{}:
+		var indexes []int
+		for idx := range v {
+			indexes = append(indexes, idx)
+		}
+		sort.Ints(indexes)
+
+		var results []interface{}
+		for _, idx := range indexes {
+			if result, isBlank := normalizeRowValue(v[idx]); !isBlank {
+				results = append(results, result)
+			}
+		}
+		return results, len(results) == 0
+	}
+	return value, value == nil
+}
+
 var (
 	isCurrentLevel = regexp.MustCompile("^[^.]+$")
 	isNextLevel    = regexp.MustCompile(`^(([^.\[\]]+)(\[\d+\])?)(?:(\.[^.]+)+)$`)
@@ -158,21 +325,106 @@ func ConvertFormToMetaValues(request *http.Request, metaors []Metaor, prefix str
 	return metaValues, nil
 }
 
-// Decode decode context to result according to resource definition
+// ErrUnsupportedMediaType no decoder registered for request's content type
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// Decoder decode request to meta values, result is the record that will be decoded to, could be used to apply patches
+type Decoder func(context *qor.Context, result interface{}, metaors []Metaor) (*MetaValues, error)
+
+var decoders = map[string]Decoder{}
+
+func init() {
+	jsonDecoder := func(context *qor.Context, result interface{}, metaors []Metaor) (*MetaValues, error) {
+		defer context.Request.Body.Close()
+		return ConvertJSONToMetaValues(context.Request.Body, metaors)
+	}
+
+	formDecoder := func(context *qor.Context, result interface{}, metaors []Metaor) (*MetaValues, error) {
+		return ConvertFormToMetaValues(context.Request, metaors, "QorResource.")
+	}
+
+	RegisterDecoder("application/json", jsonDecoder)
+	RegisterDecoder("text/json", jsonDecoder)
+	RegisterDecoder("application/x-www-form-urlencoded", formDecoder)
+	RegisterDecoder("multipart/form-data", formDecoder)
+	// requests without body, e.g: actions without arguments
+	RegisterDecoder("", formDecoder)
+}
+
+// RegisterDecoder register decoder for media type, e.g: `application/x-yaml`, registered decoder will be replaced
+func RegisterDecoder(mediaType string, decoder Decoder) {
+	decoders[strings.ToLower(mediaType)] = decoder
+}
+
+// GetDecoder get decoder for content type, media types with suffix `+json` will be decoded as json if no decoder registered for it
+func GetDecoder(contentType string) (Decoder, error) {
+	mediaType := strings.ToLower(strings.TrimSpace(contentType))
+	if contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedMediaType, contentType)
+		}
+	}
+
+	if decoder, ok := decoders[mediaType]; ok {
+		return decoder, nil
+	}
+
+	if strings.HasSuffix(mediaType, "+json") {
+		if decoder, ok := decoders["application/json"]; ok {
+			return decoder, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedMediaType, contentType)
+}
+
+// Decode decode context to result according to resource definition, request will be decoded with registered decoder of its content type
+// for `PATCH` requests, only changed fields will be updated when saving the result with the same context
 func Decode(context *qor.Context, result interface{}, res Resourcer) error {
 	var errors qor.Errors
-	var err error
-	var metaValues *MetaValues
-	metaors := res.GetMetas([]string{})
 
-	if strings.Contains(context.Request.Header.Get("Content-Type"), "json") {
-		metaValues, err = ConvertJSONToMetaValues(context.Request.Body, metaors)
-		context.Request.Body.Close()
-	} else {
-		metaValues, err = ConvertFormToMetaValues(context.Request, metaors, "QorResource.")
+	decoder, err := GetDecoder(context.Request.Header.Get("Content-Type"))
+	if err != nil {
+		return err
 	}
 
+	var snapshot map[string]interface{}
+	if context.Request.Method == "PATCH" {
+		snapshot = snapshotFields(result)
+	}
+
+	metaValues, err := decoder(context, result, res.GetMetas([]string{}))
 	errors.AddError(err)
 	errors.AddError(DecodeToResource(res, result, metaValues, context).Start())
+
+	if snapshot != nil && !errors.HasError() {
+		SetUpdateFields(context, result, changedFields(snapshot, result, metaValues))
+	}
 	return errors
 }
+
+// DecodeRowsToResource decode rows of meta values to new records of the resource, return a pointer of the records slice,
+// which could be saved with CallSaveMany, errors will be addressed with row's index, e.g: `[2].Name`
+func DecodeRowsToResource(res Resourcer, rows []*MetaValues, context *qor.Context) (interface{}, error) {
+	var (
+		errs    qor.Errors
+		results = res.NewSlice()
+		slice   = reflect.ValueOf(results).Elem()
+	)
+
+	for idx, metaValues := range rows {
+		record := res.NewStruct()
+		errs.AddError(qor.PrefixErrorPath(fmt.Sprintf("[%v]", idx), DecodeToResource(res, record, metaValues, context).Start()))
+
+		if slice.Type().Elem().Kind() == reflect.Ptr {
+			slice.Set(reflect.Append(slice, reflect.ValueOf(record)))
+		} else {
+			slice.Set(reflect.Append(slice, reflect.ValueOf(record).Elem()))
+		}
+	}
+
+	if errs.HasError() {
+		return results, errs
+	}
+	return results, nil
+}
@@ -0,0 +1,158 @@
+package resource_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+)
+
+func formatMetaValues(metaValues *resource.MetaValues) string {
+	var results []string
+	for _, metaValue := range metaValues.Values {
+		if metaValue.MetaValues != nil {
+			results = append(results, fmt.Sprintf("%v[%v]{%v}", metaValue.Name, metaValue.Index, formatMetaValues(metaValue.MetaValues)))
+		} else {
+			results = append(results, fmt.Sprintf("%v=%v", metaValue.Name, metaValue.Value))
+		}
+	}
+	sort.Strings(results)
+	return strings.Join(results, " ")
+}
+
+func TestConvertCSVToMetaValues(t *testing.T) {
+	csv := "Name,Address.City,Items[0].SKU,Items[0].Quantity,Items[1].SKU,Items[1].Quantity\n" +
+		"jinzhu,Shanghai,A001,1,,\n" +
+		",,,,,\n" +
+		"\"qor, inc\",,A002,2,A003,3\n"
+
+	rows, err := resource.ConvertCSVToMetaValues(strings.NewReader(csv), nil)
+	if err != nil {
+		t.Fatalf("no error should happen when convert csv, but got %v", err)
+	}
+
+	expected := []string{
+		"Address[0]{City=Shanghai} Items[0]{Quantity=1 SKU=A001} Name=jinzhu",
+		"Items[0]{Quantity=2 SKU=A002} Items[1]{Quantity=3 SKU=A003} Name=qor, inc",
+	}
+
+	if len(rows) != len(expected) {
+		t.Fatalf("blank rows should be skipped, expect %v rows, but got %v", len(expected), len(rows))
+	}
+
+	for idx, row := range rows {
+		if result := formatMetaValues(row); result != expected[idx] {
+			t.Errorf("row %v should be converted to %v, but got %v", idx, expected[idx], result)
+		}
+	}
+}
+
+func TestConvertXLSXToMetaValues(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		writer = zip.NewWriter(&buf)
+		files  = map[string]string{
+			"xl/workbook.xml":            `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Products" sheetId="1" r:id="rId2"/></sheets></workbook>`,
+			"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId2" Target="worksheets/products.xml"/></Relationships>`,
+			"xl/sharedStrings.xml":       `<sst><si><t>Name</t></si><si><t>Items[0].SKU</t></si><si><r><t>Available</t></r><r><t>On</t></r></si><si><t>qor</t></si></sst>`,
+			"xl/styles.xml":              `<styleSheet><cellXfs><xf numFmtId="0"/><xf numFmtId="14"/></cellXfs></styleSheet>`,
+			"xl/worksheets/products.xml": `<worksheet><sheetData>` +
+				`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+				`<row r="3"><c r="A3" t="s"><v>3</v></c><c r="C3" s="1"><v>44197</v></c></row>` +
+				`<row r="4"><c r="A4" t="inlineStr"><is><t>admin</t></is></c><c r="B4"><v>12</v></c></row>` +
+				`</sheetData></worksheet>`,
+		}
+	)
+
+	for name, content := range files {
+		w, _ := writer.Create(name)
+		w.Write([]byte(content))
+	}
+	writer.Close()
+
+	rows, err := resource.ConvertXLSXToMetaValues(&buf, nil)
+	if err != nil {
+		t.Fatalf("no error should happen when convert xlsx, but got %v", err)
+	}
+
+	expected := []string{
+		"AvailableOn=2021-01-01 Name=qor",
+		"Items[0]{SKU=12} Name=admin",
+	}
+
+	if len(rows) != len(expected) {
+		t.Fatalf("expect %v rows, but got %v", len(expected), len(rows))
+	}
+
+	for idx, row := range rows {
+		if result := formatMetaValues(row); result != expected[idx] {
+			t.Errorf("row %v should be converted to %v, but got %v", idx, expected[idx], result)
+		}
+	}
+}
+
+type testMetaor struct {
+	*resource.Meta
+}
+
+func (metaor testMetaor) GetResource() resource.Resourcer { return metaor.Meta.Resource }
+
+func (testMetaor) GetMetas() []resource.Metaor { return nil }
+
+func TestConvertPatchToMetaValues(t *testing.T) {
+	type Product struct {
+		Name string
+		Tags []string
+		Code string
+	}
+
+	var (
+		product = &Product{Name: "jacket", Tags: []string{"winter", "men"}, Code: "J001"}
+		metaors []resource.Metaor
+	)
+
+	for _, name := range []string{"Name", "Tags", "Code"} {
+		name := name
+		metaors = append(metaors, testMetaor{&resource.Meta{Name: name, Valuer: func(record interface{}, context *qor.Context) interface{} {
+			return reflect.Indirect(reflect.ValueOf(record)).FieldByName(name).Interface()
+		}}})
+	}
+
+	mergePatch := `{"Tags": ["summer"], "Code": null}`
+	metaValues, err := resource.ConvertMergePatchToMetaValues(strings.NewReader(mergePatch), product, metaors, &qor.Context{})
+	if err != nil {
+		t.Fatalf("no error should happen when convert merge patch, but got %v", err)
+	}
+
+	if result := formatMetaValues(metaValues); result != "Code=<nil> Tags=[summer]" {
+		t.Errorf("only patched metas should be converted, but got %v", result)
+	}
+
+	jsonPatch := `[
+		{"op": "test", "path": "/Name", "value": "jacket"},
+		{"op": "add", "path": "/Tags/-", "value": "sale"},
+		{"op": "remove", "path": "/Tags/0"},
+		{"op": "copy", "from": "/Tags/0", "path": "/Tags/0"},
+		{"op": "move", "from": "/Code", "path": "/Name"}
+	]`
+	metaValues, err = resource.ConvertJSONPatchToMetaValues(strings.NewReader(jsonPatch), product, metaors, &qor.Context{})
+	if err != nil {
+		t.Fatalf("no error should happen when convert json patch, but got %v", err)
+	}
+
+	if result := formatMetaValues(metaValues); result != "Code=<nil> Name=J001 Tags=[men men sale]" {
+		t.Errorf("json patch should be applied, but got %v", result)
+	}
+
+	failedPatch := `[{"op": "test", "path": "/Name", "value": "shirt"}, {"op": "replace", "path": "/Name", "value": "shirt"}]`
+	if _, err := resource.ConvertJSONPatchToMetaValues(strings.NewReader(failedPatch), product, metaors, &qor.Context{}); !errors.Is(err, resource.ErrInvalidPatch) {
+		t.Errorf("json patch with failed test should return error, but got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package resource
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConvertXLSXToMetaValues convert first sheet of xlsx file to meta values, the same as ConvertCSVToMetaValues
+func ConvertXLSXToMetaValues(reader io.Reader, metaors []Metaor) ([]*MetaValues, error) {
+	rows, err := readXLSXRows(reader)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertRowsToMetaValues(rows, metaors)
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (text xlsxRichText) String() string {
+	if len(text.Runs) == 0 {
+		return text.Text
+	}
+
+	var result string
+	for _, run := range text.Runs {
+		result += run.Text
+	}
+	return result
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxStyles struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string        `xml:"r,attr"`
+			Type   string        `xml:"t,attr"`
+			Style  int           `xml:"s,attr"`
+			Value  string        `xml:"v"`
+			Inline *xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSXRows(reader io.Reader) ([][]string, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	decode := func(name string, value interface{}) error {
+		file, ok := files[name]
+		if !ok {
+			return nil
+		}
+
+		r, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		return xml.NewDecoder(r).Decode(value)
+	}
+
+	var (
+		workbook      xlsxWorkbook
+		relationships xlsxRelationships
+		sharedStrings xlsxSharedStrings
+		styles        xlsxStyles
+		sheet         xlsxSheet
+		sheetName     = "xl/worksheets/sheet1.xml"
+	)
+
+	for name, value := range map[string]interface{}{
+		"xl/workbook.xml":            &workbook,
+		"xl/_rels/workbook.xml.rels": &relationships,
+		"xl/sharedStrings.xml":       &sharedStrings,
+		"xl/styles.xml":              &styles,
+	} {
+		if err := decode(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	// find the first sheet
+	if len(workbook.Sheets) > 0 {
+		for _, relationship := range relationships.Relationships {
+			if relationship.ID == workbook.Sheets[0].RID {
+				if strings.HasPrefix(relationship.Target, "/") {
+					sheetName = strings.TrimPrefix(relationship.Target, "/")
+				} else {
+					sheetName = path.Join("xl", relationship.Target)
+				}
+			}
+		}
+	}
+
+	if _, ok := files[sheetName]; !ok {
+		return nil, errors.New("invalid xlsx file, no sheet found")
+	}
+
+	if err := decode(sheetName, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		// rows without values might be omitted
+		if row.Index > len(rows)+1 {
+			rows = append(rows, make([][]string, row.Index-len(rows)-1)...)
+		}
+
+		var cells []string
+		for _, cell := range row.Cells {
+			if col := xlsxColumnIndex(cell.Ref); col > len(cells) {
+				cells = append(cells, make([]string, col-len(cells))...)
+			}
+
+			var value = cell.Value
+			switch cell.Type {
+			case "s":
+				if idx, err := strconv.Atoi(cell.Value); err == nil && idx < len(sharedStrings.Items) {
+					value = sharedStrings.Items[idx].String()
+				}
+			case "inlineStr":
+				if cell.Inline != nil {
+					value = cell.Inline.String()
+				}
+			case "b":
+				value = strconv.FormatBool(cell.Value == "1")
+			case "", "n":
+				if cell.Style < len(styles.CellXfs) && value != "" {
+					numFmtID := styles.CellXfs[cell.Style].NumFmtID
+					formatCode := ""
+					for _, numFmt := range styles.NumFmts {
+						if numFmt.ID == numFmtID {
+							formatCode = numFmt.Code
+						}
+					}
+
+					if isXLSXDateFormat(numFmtID, formatCode) {
+						value = xlsxDate(value)
+					}
+				}
+			}
+			cells = append(cells, value)
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// xlsxColumnIndex convert cell reference to column index, e.g: `A1` => 0, `AB12` => 27
+func xlsxColumnIndex(ref string) int {
+	if ref == "" {
+		return -1
+	}
+
+	var col int
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
+
+func isXLSXDateFormat(numFmtID int, formatCode string) bool {
+	// built-in date formats
+	if (numFmtID >= 14 && numFmtID <= 22) || (numFmtID >= 45 && numFmtID <= 47) {
+		return true
+	}
+
+	// remove quoted texts, escaped chars and colors, e.g: `[Red]"Date: "yyyy-mm-dd`
+	var (
+		code    = strings.ToLower(formatCode)
+		cleaned strings.Builder
+		quoted  bool
+		bracket bool
+	)
+
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			bracket = true
+		case c == ']':
+			bracket = false
+		case bracket:
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		default:
+			cleaned.WriteByte(c)
+		}
+	}
+	return strings.ContainsAny(cleaned.String(), "ymdhs")
+}
+
+// xlsxDate convert excel's serial date number to time string
+func xlsxDate(value string) string {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+
+	days, fraction := math.Modf(serial)
+	t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(days)).Add(time.Duration(math.Round(fraction*86400)) * time.Second)
+
+	if fraction == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
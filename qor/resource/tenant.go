@@ -0,0 +1,91 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/utils"
+	"github.com/saitofun/qor/roles"
+)
+
+// ErrNoTenant context has no tenant, so records of resources that have a tenant column couldn't be saved with it
+var ErrNoTenant = fmt.Errorf("%w: no tenant", roles.ErrPermissionDenied)
+
+// SetTenantColumn enable multi-tenancy with a field name or column name, records will be scoped with context's tenant when finding,
+// saving and deleting them, and new records will be created with it
+func (res *Resource) SetTenantColumn(name string) {
+	schema, err := gorm.Parse(res.Value)
+	if err != nil {
+		utils.ExitWithMsg(err)
+	}
+
+	field := schema.LookUpField(name)
+	if field == nil || field.DBName == "" {
+		utils.ExitWithMsg(fmt.Sprintf("tenant column %v not found for resource %v", name, res.Name))
+	}
+	res.tenantField = field
+}
+
+// GetTenantField get field used to scope records with tenant, return nil if not enabled
+func (res *Resource) GetTenantField() *gorm.Field {
+	return res.tenantField
+}
+
+// ScopeTenant scope db with context's tenant, db won't be scoped if multi-tenancy is not enabled or context could access all tenants,
+// no records will be found if context has no tenant
+func (res *Resource) ScopeTenant(db *gorm.DB, context *qor.Context) *gorm.DB {
+	if sql, args := res.tenantCondition(context); sql != "" {
+		return db.Where(sql, args...)
+	}
+	return db
+}
+
+func (res *Resource) tenantCondition(context *qor.Context) (string, []interface{}) {
+	if res.tenantField == nil || context == nil {
+		return "", nil
+	}
+
+	tenant := context.GetTenant()
+	if tenant == qor.AllTenants {
+		return "", nil
+	}
+
+	// contexts without tenant could not access any records
+	if tenant == nil {
+		return "1 = 0", nil
+	}
+
+	stmt := context.GetDB().Statement
+	return fmt.Sprintf("%v.%v = ?", stmt.Quote(res.tenantField.Schema.Table), stmt.Quote(res.tenantField.DBName)), []interface{}{tenant}
+}
+
+// withTenantCondition append tenant condition to query, used to find records with primary keys
+func (res *Resource) withTenantCondition(query string, args []interface{}, context *qor.Context) (string, []interface{}) {
+	if query == "" {
+		return query, args
+	}
+
+	if sql, tenantArgs := res.tenantCondition(context); sql != "" {
+		return fmt.Sprintf("%v AND %v", query, sql), append(args, tenantArgs...)
+	}
+	return query, args
+}
+
+// setTenant set context's tenant to record, so records could only be saved into current tenant,
+// contexts could access all tenants save records with their own tenant values
+func (res *Resource) setTenant(record interface{}, context *qor.Context) error {
+	if res.tenantField == nil || context == nil {
+		return nil
+	}
+
+	switch tenant := context.GetTenant(); tenant {
+	case qor.AllTenants:
+		return nil
+	case nil:
+		return ErrNoTenant
+	default:
+		return res.tenantField.Set(reflect.ValueOf(record), tenant)
+	}
+}
@@ -0,0 +1,114 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/roles"
+)
+
+// ErrNotSoftDeletable records of resource couldn't be restored as they are deleted permanently
+var ErrNotSoftDeletable = errors.New("records are not soft deletable")
+
+// GetSoftDeleteField get field used to soft delete records, e.g: `DeletedAt` of gorm.Model, return nil if records are deleted permanently
+func (res *Resource) GetSoftDeleteField() *gorm.Field {
+	schema, err := gorm.Parse(res.Value)
+	if err != nil {
+		return nil
+	}
+
+	for _, field := range schema.Fields {
+		if field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) && field.DBName != "" {
+			return field
+		}
+	}
+	return nil
+}
+
+// IsDeleted check if the record has been soft deleted
+func (res *Resource) IsDeleted(record interface{}) bool {
+	if field := res.GetSoftDeleteField(); field != nil && record != nil {
+		if value := reflect.ValueOf(record); value.Kind() == reflect.Ptr && value.Elem().Type() == field.Schema.ModelType {
+			deletedAt, _ := field.ValueOf(value)
+			if deletedAt, ok := deletedAt.(gorm.DeletedAt); ok {
+				return deletedAt.Valid
+			}
+		}
+	}
+	return false
+}
+
+// ScopeTrash scope db to soft deleted records, return db unchanged if records are deleted permanently
+func (res *Resource) ScopeTrash(db *gorm.DB, context *qor.Context) *gorm.DB {
+	if field := res.GetSoftDeleteField(); field != nil {
+		return db.Unscoped().Where(fmt.Sprintf("%v.%v IS NOT NULL", db.Statement.Quote(field.Schema.Table), db.Statement.Quote(field.DBName)))
+	}
+	return db
+}
+
+// CallRestore call restore method, soft deleted record of context's ResourceID will be restored
+func (res *Resource) CallRestore(result interface{}, context *qor.Context) error {
+	defer res.invalidateCache(result, context)
+	return res.dryRun(context, result, func() error {
+		return res.RestoreHandler(result, context)
+	})
+}
+
+// CallPurge call purge method, record of context's ResourceID will be deleted permanently even if it has been soft deleted,
+// both delete and purge permissions are required
+func (res *Resource) CallPurge(result interface{}, context *qor.Context) error {
+	defer res.invalidateCache(result, context)
+	return res.dryRun(context, result, func() error {
+		return res.PurgeHandler(result, context)
+	})
+}
+
+func (res *Resource) restoreHandler(result interface{}, context *qor.Context) error {
+	if !res.HasPermission(roles.Restore, context) {
+		return roles.ErrPermissionDenied
+	}
+
+	field := res.GetSoftDeleteField()
+	if field == nil {
+		return ErrNotSoftDeletable
+	}
+
+	if sql, args := res.ToPrimaryQueryParams(context.ResourceID, context); sql != "" {
+		db := res.ScopeTrash(context.GetDB().Session(&gorm.Session{}), context)
+		if err := db.First(result, append([]interface{}{sql}, args...)...).Error; err != nil {
+			return err
+		}
+
+		if err := res.checkRecordPolicies(roles.Restore, result, context); err != nil {
+			return err
+		}
+
+		if err := context.GetDB().Session(&gorm.Session{}).Unscoped().Model(result).Update(field.DBName, nil).Error; err != nil {
+			return err
+		}
+		return field.Set(reflect.ValueOf(result), gorm.DeletedAt{})
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (res *Resource) purgeHandler(result interface{}, context *qor.Context) error {
+	if !res.HasPermission(roles.Delete, context) || !res.HasPermission(roles.Purge, context) {
+		return roles.ErrPermissionDenied
+	}
+
+	if sql, args := res.ToPrimaryQueryParams(context.ResourceID, context); sql != "" {
+		db := context.GetDB().Session(&gorm.Session{}).Unscoped()
+		if err := db.First(result, append([]interface{}{sql}, args...)...).Error; err != nil {
+			return err
+		}
+
+		if err := res.checkRecordPolicies(roles.Purge, result, context); err != nil {
+			return err
+		}
+		return db.Delete(result).Error
+	}
+	return gorm.ErrRecordNotFound
+}
@@ -0,0 +1,151 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/saitofun/qor/qor"
+)
+
+// Typed typed wrapper of resource for records of T, records are passed as *T and []*T instead of interface{},
+// so mistakes of handlers, validators and processors are caught at compile time
+//
+//	products := resource.Of[Product](resource.New(&Product{}))
+//	products.AddValidatorFunc("name", func(product *Product, metaValues *resource.MetaValues, context *qor.Context) error { ... })
+//	product, err := products.FindOne(context)
+type Typed[T any] struct {
+	*Resource
+}
+
+// Of wrap resource as typed resource of T, resource's value should be *T, a new resource will be created if res is nil
+func Of[T any](res *Resource) *Typed[T] {
+	if res == nil {
+		res = New(new(T))
+	}
+
+	if _, ok := res.NewStruct().(*T); !ok {
+		panic(fmt.Sprintf("resource %v is not a resource of %T", res.Name, new(T)))
+	}
+	return &Typed[T]{Resource: res}
+}
+
+// FindOne find record with context's ResourceID
+func (typed *Typed[T]) FindOne(context *qor.Context) (*T, error) {
+	result := new(T)
+	if err := typed.CallFindOne(result, nil, context); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindMany find records with context
+func (typed *Typed[T]) FindMany(context *qor.Context) ([]*T, error) {
+	var results []*T
+	if err := typed.CallFindMany(&results, context); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Save save record
+func (typed *Typed[T]) Save(record *T, context *qor.Context) error {
+	return typed.CallSave(record, context)
+}
+
+// Delete delete record with context's ResourceID
+func (typed *Typed[T]) Delete(record *T, context *qor.Context) error {
+	return typed.CallDelete(record, context)
+}
+
+// SaveMany save records in batch
+func (typed *Typed[T]) SaveMany(records []*T, context *qor.Context) error {
+	return typed.CallSaveMany(records, context)
+}
+
+// DeleteMany delete records in batch
+func (typed *Typed[T]) DeleteMany(records []*T, context *qor.Context) error {
+	return typed.CallDeleteMany(records, context)
+}
+
+// AddValidatorFunc add typed validator, validator with same name will be replaced
+func (typed *Typed[T]) AddValidatorFunc(name string, handler func(record *T, metaValues *MetaValues, context *qor.Context) error) {
+	typed.AddValidator(&Validator{
+		Name: name,
+		Handler: func(record interface{}, metaValues *MetaValues, context *qor.Context) error {
+			value, err := typedRecord[T](record)
+			if err != nil {
+				return err
+			}
+			return handler(value, metaValues, context)
+		},
+	})
+}
+
+// AddProcessorFunc add typed processor, processor with same name will be replaced
+func (typed *Typed[T]) AddProcessorFunc(name string, handler func(record *T, metaValues *MetaValues, context *qor.Context) error) {
+	typed.AddProcessor(&Processor{
+		Name: name,
+		Handler: func(record interface{}, metaValues *MetaValues, context *qor.Context) error {
+			value, err := typedRecord[T](record)
+			if err != nil {
+				return err
+			}
+			return handler(value, metaValues, context)
+		},
+	})
+}
+
+// SetFindOneHandler set typed find one handler
+func (typed *Typed[T]) SetFindOneHandler(handler func(result *T, metaValues *MetaValues, context *qor.Context) error) {
+	typed.FindOneHandler = func(result interface{}, metaValues *MetaValues, context *qor.Context) error {
+		value, err := typedRecord[T](result)
+		if err != nil {
+			return err
+		}
+		return handler(value, metaValues, context)
+	}
+}
+
+// SetFindManyHandler set typed find many handler, non-slice results, e.g: total count of admin's pagination,
+// are found with the handler set before it
+func (typed *Typed[T]) SetFindManyHandler(handler func(results *[]*T, context *qor.Context) error) {
+	fallback := typed.FindManyHandler
+	typed.FindManyHandler = func(results interface{}, context *qor.Context) error {
+		values, ok := results.(*[]*T)
+		if !ok {
+			if value := reflect.Indirect(reflect.ValueOf(results)); fallback != nil && value.Kind() != reflect.Slice {
+				return fallback(results, context)
+			}
+			return fmt.Errorf("%T is not %T", results, values)
+		}
+		return handler(values, context)
+	}
+}
+
+// SetSaveHandler set typed save handler
+func (typed *Typed[T]) SetSaveHandler(handler func(result *T, context *qor.Context) error) {
+	typed.SaveHandler = typedHandler(handler)
+}
+
+// SetDeleteHandler set typed delete handler
+func (typed *Typed[T]) SetDeleteHandler(handler func(result *T, context *qor.Context) error) {
+	typed.DeleteHandler = typedHandler(handler)
+}
+
+func typedHandler[T any](handler func(result *T, context *qor.Context) error) Handler {
+	return func(result interface{}, context *qor.Context) error {
+		value, err := typedRecord[T](result)
+		if err != nil {
+			return err
+		}
+		return handler(value, context)
+	}
+}
+
+func typedRecord[T any](record interface{}) (*T, error) {
+	value, ok := record.(*T)
+	if !ok {
+		return nil, fmt.Errorf("%T is not %T", record, value)
+	}
+	return value, nil
+}
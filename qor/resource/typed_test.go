@@ -0,0 +1,64 @@
+package resource_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
+	"github.com/saitofun/qor/qor/resource"
+	"github.com/saitofun/qor/utils/test_db"
+)
+
+func TestResource_Typed(t *testing.T) {
+	type TypedProduct struct {
+		gorm.Model
+		Name string
+	}
+
+	db := test_db.NewTestDB()
+	db.Migrator().DropTable(&TypedProduct{})
+	db.AutoMigrate(&TypedProduct{})
+
+	ctx := &qor.Context{Config: &qor.Config{DB: db}}
+	products := resource.Of[TypedProduct](nil)
+	products.AddValidatorFunc("name", func(product *TypedProduct, metaValues *resource.MetaValues, context *qor.Context) error {
+		if product.Name == "" {
+			return errors.New("name can't be blank")
+		}
+		return nil
+	})
+
+	if err := products.Validators[0].Handler(&TypedProduct{}, nil, ctx); err == nil {
+		t.Errorf("typed validator should be called with typed record")
+	}
+
+	product := &TypedProduct{Name: "product"}
+	if err := products.Save(product, ctx); err != nil {
+		t.Fatalf("product should be saved, but got %v", err)
+	}
+
+	ctx.ResourceID = products.PrimaryValueOf(product)
+	if result, err := products.FindOne(ctx); err != nil || result.Name != "product" {
+		t.Errorf("product should be found, but got %+v, %v", result, err)
+	}
+
+	if results, err := products.FindMany(ctx); err != nil || len(results) != 1 || results[0].Name != "product" {
+		t.Errorf("products should be found, but got %+v, %v", results, err)
+	}
+
+	products.SetFindManyHandler(func(results *[]*TypedProduct, context *qor.Context) error {
+		*results = append(*results, &TypedProduct{Name: "typed"})
+		return nil
+	})
+
+	if results, err := products.FindMany(ctx); err != nil || len(results) != 1 || results[0].Name != "typed" {
+		t.Errorf("typed find many handler should be used, but got %+v, %v", results, err)
+	}
+
+	var count int
+	countCtx := &qor.Context{Config: &qor.Config{DB: db.Model(&TypedProduct{}).Set("qor:getting_total_count", true)}}
+	if err := products.CallFindMany(&count, countCtx); err != nil || count == 0 {
+		t.Errorf("non-slice results should be found with the original handler, but got %v, %v", count, err)
+	}
+}
@@ -0,0 +1,76 @@
+package qor
+
+import (
+	"net"
+	"strings"
+)
+
+// TenantResolver resolve tenant of current context, return nil if the context doesn't belong to any tenant, records of resources
+// that have a tenant column are not accessible for it, return AllTenants for contexts that could access all tenants, e.g: super administrators
+type TenantResolver func(context *Context) interface{}
+
+type allTenants struct{}
+
+func (allTenants) String() string {
+	return "all tenants"
+}
+
+// AllTenants tenant of contexts that could access records of all tenants, records won't be scoped with tenant for them
+var AllTenants interface{} = allTenants{}
+
+// TenantCurrentUser current user that belongs to a tenant, used by TenantFromCurrentUser
+type TenantCurrentUser interface {
+	GetTenantID() interface{}
+}
+
+// TenantFromCurrentUser resolve tenant from current user, the user need to implement TenantCurrentUser
+func TenantFromCurrentUser() TenantResolver {
+	return func(context *Context) interface{} {
+		if user, ok := context.CurrentUser.(TenantCurrentUser); ok {
+			return user.GetTenantID()
+		}
+		return nil
+	}
+}
+
+// TenantFromHeader resolve tenant from request header, e.g: `X-Tenant-ID`, the header is set by clients and not checked
+// against current user, so only use it behind trusted proxies that set the header, e.g: an authenticating gateway
+func TenantFromHeader(name string) TenantResolver {
+	return func(context *Context) interface{} {
+		if context.Request != nil {
+			if tenant := context.Request.Header.Get(name); tenant != "" {
+				return tenant
+			}
+		}
+		return nil
+	}
+}
+
+// TenantFromSubdomain resolve tenant from request host's subdomain of domain, e.g: `acme` for `acme.example.com` with domain `example.com`
+func TenantFromSubdomain(domain string) TenantResolver {
+	return func(context *Context) interface{} {
+		if context.Request == nil {
+			return nil
+		}
+
+		host := context.Request.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		if subdomain := strings.TrimSuffix(strings.ToLower(host), "."+strings.ToLower(domain)); subdomain != strings.ToLower(host) && subdomain != "" {
+			return subdomain
+		}
+		return nil
+	}
+}
+
+// GetTenant get tenant of current context, it will be resolved with config's TenantResolver if it hasn't been set,
+// the resolved tenant will be cached in the context, including nil
+func (context *Context) GetTenant() interface{} {
+	if context.Tenant == nil && !context.tenantResolved && context.Config != nil && context.Config.TenantResolver != nil {
+		context.Tenant = context.Config.TenantResolver(context)
+		context.tenantResolved = true
+	}
+	return context.Tenant
+}
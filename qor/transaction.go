@@ -0,0 +1,59 @@
+package qor
+
+import (
+	stdcontext "context"
+
+	"github.com/saitofun/qor/gorm"
+)
+
+type afterCommitKey struct{}
+
+type afterCommits struct {
+	active    bool
+	callbacks []func()
+}
+
+// Transaction run fc in a transaction of context's db, context's DB is set to the transaction when running fc,
+// callbacks registered with AfterCommit are called after the outermost transaction committed
+func (context *Context) Transaction(fc func(tx *gorm.DB) error) error {
+	originalDB := context.DB
+	defer context.SetDB(originalDB)
+
+	commits, ok := context.GetContext().Value(afterCommitKey{}).(*afterCommits)
+	if !ok {
+		commits = &afterCommits{}
+		context.SetContext(stdcontext.WithValue(context.GetContext(), afterCommitKey{}, commits))
+	}
+
+	if commits.active {
+		return context.GetDB().Transaction(func(tx *gorm.DB) error {
+			context.SetDB(tx)
+			return fc(tx)
+		})
+	}
+
+	commits.active = true
+	err := context.GetDB().Transaction(func(tx *gorm.DB) error {
+		context.SetDB(tx)
+		return fc(tx)
+	})
+
+	callbacks := commits.callbacks
+	commits.active, commits.callbacks = false, nil
+	if err == nil {
+		for _, callback := range callbacks {
+			callback()
+		}
+	}
+	return err
+}
+
+// AfterCommit call fc after the transaction opened with Transaction committed, it won't be called if the transaction is rollbacked,
+// fc is called immediately if context's db is not in a transaction
+func (context *Context) AfterCommit(fc func()) {
+	if commits, ok := context.GetContext().Value(afterCommitKey{}).(*afterCommits); ok && commits.active {
+		commits.callbacks = append(commits.callbacks, fc)
+		return
+	}
+	fc()
+}
@@ -19,11 +19,25 @@ const (
 	Delete PermissionMode = "delete"
 	// CRUD predefined permission mode, create+read+update+delete permission
 	CRUD PermissionMode = "crud"
+	// Restore predefined permission mode, restore soft deleted records permission, not included in CRUD, denied unless allowed explicitly
+	Restore PermissionMode = "restore"
+	// Purge predefined permission mode, delete records permanently permission, not included in CRUD, denied unless allowed explicitly
+	Purge PermissionMode = "purge"
+	// Export predefined permission mode, export records permission, not included in CRUD
+	Export PermissionMode = "export"
 )
 
 // ErrPermissionDenied no permission error
 var ErrPermissionDenied = errors.New("permission denied")
 
+// explicitModes permission modes that are denied unless they are allowed explicitly, as they can't be undone or bring deleted records back
+var explicitModes = map[PermissionMode]bool{Restore: true, Purge: true}
+
+// IsExplicitMode check the permission mode is denied unless it is allowed explicitly, e.g: Restore, Purge
+func IsExplicitMode(mode PermissionMode) bool {
+	return explicitModes[mode]
+}
+
 // Permission a struct contains permission definitions
 type Permission struct {
 	Role         *Role
@@ -121,9 +135,9 @@ func (permission Permission) HasPermission(mode PermissionMode, roles ...interfa
 		}
 	}
 
-	// return true if haven't define allowed roles
+	// return true if haven't define allowed roles, except modes that need to be allowed explicitly
 	if len(permission.AllowedRoles) == 0 {
-		return true
+		return !IsExplicitMode(mode)
 	}
 
 	if AllowedRoles := permission.AllowedRoles[mode]; AllowedRoles != nil {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/saitofun/qor/gorm"
+	"github.com/saitofun/qor/qor"
 )
 
 // NewError generate a new error for a model's field
@@ -12,10 +13,16 @@ func NewError(resource interface{}, column, err string) error {
 	return &Error{Resource: resource, Column: column, Message: err}
 }
 
-// Error is a validation error struct that hold model, column and error message
+// NewErrorWithCode generate a new error for a model's field with a machine-readable code, e.g: `required`, `email`
+func NewErrorWithCode(resource interface{}, column, code, err string) error {
+	return &Error{Resource: resource, Column: column, Code: code, Message: err}
+}
+
+// Error is a validation error struct that hold model, column, error code and error message
 type Error struct {
 	Resource interface{}
 	Column   string
+	Code     string
 	Message  string
 }
 
@@ -30,3 +37,16 @@ func (e Error) Label() string {
 func (e Error) Error() string {
 	return fmt.Sprintf("%v", e.Message)
 }
+
+// ErrorPath return the column as error's path, used to address the error to a meta
+func (e Error) ErrorPath() string {
+	return e.Column
+}
+
+// ErrorCode return error's code, `invalid` if it hasn't been set
+func (e Error) ErrorCode() string {
+	if e.Code == "" {
+		return qor.ErrorCodeInvalid
+	}
+	return e.Code
+}
@@ -3,6 +3,7 @@ package validations_test
 import (
 	"testing"
 
+	"github.com/saitofun/qor/qor"
 	"github.com/saitofun/qor/validations"
 )
 
@@ -12,3 +13,21 @@ func TestNewError(t *testing.T) {
 	label := e.(*validations.Error).Label()
 	t.Logf(label)
 }
+
+func TestErrorCode(t *testing.T) {
+	var errs qor.Errors
+	errs.AddError(validations.NewError(&User{}, "Name", "Empty"), validations.NewErrorWithCode(&User{}, "Email", "email", "Email is not a valid email address"))
+
+	results := errs.GetFieldErrors()
+	if len(results) != 2 {
+		t.Fatalf("should get 2 errors, but got %v", len(results))
+	}
+
+	if results[0].Path != "Name" || results[0].Code != qor.ErrorCodeInvalid {
+		t.Errorf("error without code should be invalid, but got %#v", results[0])
+	}
+
+	if results[1].Path != "Email" || results[1].Code != "email" {
+		t.Errorf("error code should be email, but got %#v", results[1])
+	}
+}
@@ -44,7 +44,7 @@ func formattedError(err govalidator.Error, res interface{}) error {
 	} else if strings.Index(msg, "as email") >= 0 {
 		msg = fmt.Sprintf("%v is not a valid email address", attr)
 	}
-	return NewError(res, attr, msg)
+	return NewErrorWithCode(res, attr, err.Validator, msg)
 
 }
 